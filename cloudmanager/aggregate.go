@@ -116,6 +116,12 @@ type increaseAggregateCapacityRequest struct {
 	CapacityToAdd        diskSize `structs:"capacityToAdd"`
 }
 
+type renameAggregateRequest struct {
+	WorkingEnvironmentID string `structs:"workingEnvironmentId"`
+	Name                 string `structs:"name"`
+	NewName              string `structs:"newName"`
+}
+
 type updateAggregateIopsThroughputRequest struct {
 	WorkingEnvironmentID string `structs:"workingEnvironmentId"`
 	Name                 string `structs:"name"`
@@ -181,6 +187,31 @@ func (c *Client) getAggregate(request aggregateRequest, name string, sourceWorki
 	return aggregateResult{}, nil
 }
 
+// deleteAggregateIfEmpty deletes the aggregate when it no longer holds any volume and returns true if it was deleted
+func (c *Client) deleteAggregateIfEmpty(workingEnvironmentID string, workingEnvironmentType string, name string, clientID string, isSaaS bool, connectorIP string) (bool, error) {
+	request := aggregateRequest{}
+	request.WorkingEnvironmentID = workingEnvironmentID
+	aggr, err := c.getAggregate(request, name, workingEnvironmentType, clientID, isSaaS, connectorIP)
+	if err != nil {
+		return false, err
+	}
+	if aggr.Name != name || aggr.IsRoot {
+		return false, nil
+	}
+	if len(aggr.Volumes) > 0 {
+		log.Printf("Aggregate %s still holds %d volumes, keeping it", name, len(aggr.Volumes))
+		return false, nil
+	}
+	log.Printf("Deleting empty aggregate %s", name)
+	deleteRequest := deleteAggregateRequest{}
+	deleteRequest.WorkingEnvironmentID = workingEnvironmentID
+	deleteRequest.Name = name
+	if err := c.deleteAggregate(deleteRequest, clientID, isSaaS, connectorIP); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // create aggregate
 func (c *Client) createAggregate(request *createAggregateRequest, clientID string, isSaaS bool, connectorIP string) (aggregateResult, error) {
 	log.Printf("createAggregate %v... ", (*request).Name)
@@ -327,6 +358,44 @@ func (c *Client) updateAggregate(request updateAggregateRequest, clientID string
 	return err
 }
 
+// renameAggregate renames an aggregate in place
+func (c *Client) renameAggregate(request renameAggregateRequest, clientID string, isSaaS bool, connectorIP string) error {
+	log.Printf("renameAggregate %s to %s", request.Name, request.NewName)
+
+	params := structs.Map(request)
+	hostType := "CloudManagerHost"
+	if !isSaaS {
+		hostType = "http://" + connectorIP
+	}
+
+	rootURL, _, err := c.getAPIRoot(request.WorkingEnvironmentID, clientID, isSaaS, connectorIP)
+	if err != nil {
+		log.Print("renameAggregate: Cannot get API root.")
+		return err
+	}
+	baseURL := fmt.Sprintf("%s/aggregates/%s/%s", rootURL, request.WorkingEnvironmentID, request.Name)
+
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("PUT", baseURL, params, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("renameAggregate request failed")
+		return err
+	}
+
+	responseError := apiResponseChecker(statusCode, response, "renameAggregate")
+	if responseError != nil {
+		return responseError
+	}
+
+	log.Print("Wait for aggregate rename.")
+	if isSaaS {
+		err = c.waitOnCompletion(onCloudRequestID, "Aggregate", "rename", 10, 60, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "Aggregate", "rename", 10, 60, clientID, connectorIP)
+	}
+
+	return err
+}
+
 // increaseAggregateCapacity increases the capacity of an aggregate using Amazon EBS Elastic Volumes
 func (c *Client) increaseAggregateCapacity(request increaseAggregateCapacityRequest, clientID string, isSaaS bool, connectorIP string) error {
 	log.Printf("increaseAggregateCapacity for aggregate %s by %d %s", request.AggregateName, request.CapacityToAdd.Size, request.CapacityToAdd.Unit)
@@ -35,6 +35,39 @@ type cbsVolume struct {
 	BackupPolicy backupPolicy `structs:"backup-policy,omitempty"`
 }
 
+// cbsUpdateRequest the backup settings of a working environment that can be modified in place
+type cbsUpdateRequest struct {
+	BackupPolicy        backupPolicy `structs:"backup-policy"`
+	AutoBackupEnabled   bool         `structs:"auto-backup-enabled"`
+	MaxTransferRate     int          `structs:"max-transfer-rate"`
+	ArchiveStorageClass string       `structs:"archive-storage-class,omitempty"`
+}
+
+// cbsRestoreRequest the users input for restoring a backup to a new volume, or files of a backup to an existing volume
+type cbsRestoreRequest struct {
+	Source cbsRestoreSource `structs:"source"`
+	Target cbsRestoreTarget `structs:"target"`
+	File   []cbsRestoreFile `structs:"file,omitempty"`
+}
+
+type cbsRestoreSource struct {
+	WorkingEnvironmentID string `structs:"working-environment-id"`
+	VolumeID             string `structs:"volume-id"`
+	Snapshot             string `structs:"snapshot"`
+}
+
+type cbsRestoreTarget struct {
+	WorkingEnvironmentID string `structs:"working-environment-id"`
+	Svm                  string `structs:"svm,omitempty"`
+	VolumeName           string `structs:"volume-name"`
+	Aggregate            string `structs:"aggregate,omitempty"`
+	Path                 string `structs:"path,omitempty"`
+}
+
+type cbsRestoreFile struct {
+	Path string `structs:"path"`
+}
+
 type awsDetails struct {
 	AccountID           string          `structs:"account-id,omitempty"`
 	AccessKey           string          `structs:"access-key,omitempty"`
@@ -163,6 +196,7 @@ type backupPolicyResult struct {
 	Name            string       `json:"name"`
 	Rules           []ruleResult `json:"rule"`
 	ArchiveAfteDays string       `json:"archive-after-days"`
+	ObjectLock      string       `json:"object-lock"`
 }
 
 type ruleResult struct {
@@ -214,14 +248,20 @@ type cbsWEResult struct {
 	RemoteMccID             string             `json:"remote-mcc-id"`
 }
 
+type cbsBackupPolicyListResult struct {
+	Policy []backupPolicyResult `json:"policy"`
+}
+
 type cbsGetVolumeResult struct {
 	Volume []cbsVolumeResult `json:"volume"`
 }
 
 type cbsVolumeResult struct {
-	Name          string `json:"name"`
-	ID            string `json:"file-system-id"`
-	SnapshotCount string `json:"snapshot-count"`
+	Name          string             `json:"name"`
+	ID            string             `json:"file-system-id"`
+	SnapshotCount string             `json:"snapshot-count"`
+	Mode          string             `json:"mode"`
+	BackupPolicy  backupPolicyResult `json:"backup-policy"`
 }
 
 // Create working environment cloud backup
@@ -304,6 +344,9 @@ func (c *Client) enableBackupForSingleORMultipleVolumes(cbs cbsRequest, cbsVolum
 	if err != nil {
 		errVolumeMessage := ""
 		noOfVolsFailed := 0
+		if len(cbsJobStatus) == 0 {
+			return cbsAPICallResult{}, err
+		}
 		for _, eachVolume := range cbsJobStatus[0].Data.MultiVolumeBackup.Volume {
 			if eachVolume.VolumeStatus == "FAILED" {
 				errVolumeMessage += "for volume " + volumesIDNameMap[eachVolume.ID]["name"] + ", " + eachVolume.VolumeError + "\n"
@@ -319,6 +362,255 @@ func (c *Client) enableBackupForSingleORMultipleVolumes(cbs cbsRequest, cbsVolum
 	return result, nil
 }
 
+// updateCBS modifies the backup settings of the working environment
+func (c *Client) updateCBS(cbs cbsRequest, update cbsUpdateRequest, clientID string) error {
+	log.Print("updateCBS...")
+
+	jobRetryCount := 60
+	jobWaitTime := 10
+
+	accessTokenResult, err := c.getAccessToken()
+	if err != nil {
+		log.Print("in updateCBS request, failed to get AccessToken")
+		return err
+	}
+	c.Token = accessTokenResult.Token
+	hostType := "CloudManagerHost"
+	baseURL := fmt.Sprintf("/account/%s/providers/cloudmanager_cbs/api/v3/backup/working-environment/%s", cbs.AccountID, cbs.WorkingEnvironmentID)
+	params := structs.Map(update)
+
+	log.Printf("\tparams: %+v", params)
+	statusCode, response, _, err := c.CallAPIMethod("PUT", baseURL, params, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("updateCBS request failed ", statusCode)
+		return err
+	}
+
+	responseError := apiResponseChecker(statusCode, response, "updateCBS")
+	if responseError != nil {
+		return responseError
+	}
+	return c.waitOnCBSResponseJob(response, cbs, "CBS", "update", jobRetryCount, jobWaitTime, clientID)
+}
+
+// updateCBSVolume modifies the backup policy of a volume that is already backed up
+func (c *Client) updateCBSVolume(cbs cbsRequest, volume cbsVolume, clientID string) error {
+	log.Print("updateCBSVolume...")
+
+	jobRetryCount := 60
+	jobWaitTime := 10
+
+	accessTokenResult, err := c.getAccessToken()
+	if err != nil {
+		log.Print("in updateCBSVolume request, failed to get AccessToken")
+		return err
+	}
+	c.Token = accessTokenResult.Token
+	hostType := "CloudManagerHost"
+	baseURL := fmt.Sprintf("/account/%s/providers/cloudmanager_cbs/api/v3/backup/working-environment/%s/volume/%s", cbs.AccountID, cbs.WorkingEnvironmentID, volume.VolumeID)
+	params := structs.Map(volume)
+
+	log.Printf("\tparams: %+v", params)
+	statusCode, response, _, err := c.CallAPIMethod("PUT", baseURL, params, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("updateCBSVolume request failed ", statusCode)
+		return err
+	}
+
+	responseError := apiResponseChecker(statusCode, response, "updateCBSVolume")
+	if responseError != nil {
+		return responseError
+	}
+	return c.waitOnCBSResponseJob(response, cbs, "backup for volume", "update", jobRetryCount, jobWaitTime, clientID)
+}
+
+// deactivateCBSVolume stops the backup of a volume, the existing backups of the volume are kept
+func (c *Client) deactivateCBSVolume(cbs cbsRequest, volumeID string, clientID string) error {
+	log.Print("deactivateCBSVolume...")
+
+	jobRetryCount := 60
+	jobWaitTime := 10
+
+	accessTokenResult, err := c.getAccessToken()
+	if err != nil {
+		log.Print("in deactivateCBSVolume request, failed to get AccessToken")
+		return err
+	}
+	c.Token = accessTokenResult.Token
+	hostType := "CloudManagerHost"
+	baseURL := fmt.Sprintf("/account/%s/providers/cloudmanager_cbs/api/v3/backup/working-environment/%s/volume/%s/deactivate", cbs.AccountID, cbs.WorkingEnvironmentID, volumeID)
+
+	statusCode, response, _, err := c.CallAPIMethod("POST", baseURL, nil, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("deactivateCBSVolume request failed ", statusCode)
+		return err
+	}
+
+	responseError := apiResponseChecker(statusCode, response, "deactivateCBSVolume")
+	if responseError != nil {
+		return responseError
+	}
+	return c.waitOnCBSResponseJob(response, cbs, "backup for volume", "deactivate", jobRetryCount, jobWaitTime, clientID)
+}
+
+// getCBSVolumeByID returns the backup of the volume. An empty ID is returned if the volume is not backed up.
+func (c *Client) getCBSVolumeByID(cbs cbsRequest, volumeID string, clientID string) (cbsVolumeResult, error) {
+	volumes, err := c.getCBSVolume(cbs, clientID)
+	if err != nil {
+		return cbsVolumeResult{}, err
+	}
+	for _, volume := range volumes {
+		if volume.ID == volumeID {
+			return volume, nil
+		}
+	}
+	log.Printf("Cannot find backup of volume %s", volumeID)
+	return cbsVolumeResult{}, nil
+}
+
+// createCBSBackupPolicy creates a backup policy that volumes of the working environment can be assigned to
+func (c *Client) createCBSBackupPolicy(cbs cbsRequest, policy backupPolicy, clientID string) error {
+	log.Print("createCBSBackupPolicy...")
+	baseURL := fmt.Sprintf("/account/%s/providers/cloudmanager_cbs/api/v1/backup/working-environment/%s/policy", cbs.AccountID, cbs.WorkingEnvironmentID)
+	return c.callCBSPolicyAPI("POST", baseURL, structs.Map(policy), cbs, "create", "createCBSBackupPolicy", clientID)
+}
+
+// getCBSBackupPolicy returns the backup policy with the given name. An empty name is returned if it does not exist.
+func (c *Client) getCBSBackupPolicy(cbs cbsRequest, name string, clientID string) (backupPolicyResult, error) {
+	log.Printf("getCBSBackupPolicy %s", name)
+
+	accessTokenResult, err := c.getAccessToken()
+	if err != nil {
+		log.Print("in getCBSBackupPolicy request, failed to get AccessToken")
+		return backupPolicyResult{}, err
+	}
+	c.Token = accessTokenResult.Token
+	hostType := "CloudManagerHost"
+	baseURL := fmt.Sprintf("/account/%s/providers/cloudmanager_cbs/api/v1/backup/working-environment/%s/policy", cbs.AccountID, cbs.WorkingEnvironmentID)
+
+	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("getCBSBackupPolicy request failed ", statusCode)
+		return backupPolicyResult{}, err
+	}
+
+	responseError := apiResponseChecker(statusCode, response, "getCBSBackupPolicy")
+	if responseError != nil {
+		return backupPolicyResult{}, responseError
+	}
+	var result cbsBackupPolicyListResult
+	if err := json.Unmarshal(response, &result); err != nil {
+		log.Print("Failed to unmarshall response from getCBSBackupPolicy ", err)
+		return backupPolicyResult{}, err
+	}
+	for _, policy := range result.Policy {
+		if policy.Name == name {
+			return policy, nil
+		}
+	}
+	log.Printf("Cannot find backup policy %s", name)
+	return backupPolicyResult{}, nil
+}
+
+func (c *Client) updateCBSBackupPolicy(cbs cbsRequest, policy backupPolicy, clientID string) error {
+	log.Print("updateCBSBackupPolicy...")
+	baseURL := fmt.Sprintf("/account/%s/providers/cloudmanager_cbs/api/v1/backup/working-environment/%s/policy/%s", cbs.AccountID, cbs.WorkingEnvironmentID, policy.Name)
+	return c.callCBSPolicyAPI("PUT", baseURL, structs.Map(policy), cbs, "update", "updateCBSBackupPolicy", clientID)
+}
+
+func (c *Client) deleteCBSBackupPolicy(cbs cbsRequest, name string, clientID string) error {
+	log.Print("deleteCBSBackupPolicy...")
+	baseURL := fmt.Sprintf("/account/%s/providers/cloudmanager_cbs/api/v1/backup/working-environment/%s/policy/%s", cbs.AccountID, cbs.WorkingEnvironmentID, name)
+	return c.callCBSPolicyAPI("DELETE", baseURL, nil, cbs, "delete", "deleteCBSBackupPolicy", clientID)
+}
+
+// callCBSPolicyAPI sends a request for the backup policies of a working environment and waits for its job
+func (c *Client) callCBSPolicyAPI(method string, baseURL string, params map[string]interface{}, cbs cbsRequest, task string, functionName string, clientID string) error {
+	jobRetryCount := 60
+	jobWaitTime := 10
+
+	accessTokenResult, err := c.getAccessToken()
+	if err != nil {
+		log.Print("in " + functionName + " request, failed to get AccessToken")
+		return err
+	}
+	c.Token = accessTokenResult.Token
+	hostType := "CloudManagerHost"
+
+	log.Printf("\tparams: %+v", params)
+	statusCode, response, _, err := c.CallAPIMethod(method, baseURL, params, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print(functionName+" request failed ", statusCode)
+		return err
+	}
+
+	responseError := apiResponseChecker(statusCode, response, functionName)
+	if responseError != nil {
+		return responseError
+	}
+	return c.waitOnCBSResponseJob(response, cbs, "backup policy", task, jobRetryCount, jobWaitTime, clientID)
+}
+
+// restoreCBS restores a backup to a new volume, or the files of a backup to an existing volume if files are given
+func (c *Client) restoreCBS(cbs cbsRequest, restore cbsRestoreRequest, clientID string) (cbsAPICallResult, error) {
+	log.Print("restoreCBS...")
+
+	accessTokenResult, err := c.getAccessToken()
+	if err != nil {
+		log.Print("in restoreCBS request, failed to get AccessToken")
+		return cbsAPICallResult{}, err
+	}
+	c.Token = accessTokenResult.Token
+	hostType := "CloudManagerHost"
+	baseURL := fmt.Sprintf("/account/%s/providers/cloudmanager_cbs/api/v1/restore/%s", cbs.AccountID, getCBSRestoreType(restore))
+	params := structs.Map(restore)
+
+	log.Printf("\tparams: %+v", params)
+	statusCode, response, _, err := c.CallAPIMethod("POST", baseURL, params, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("restoreCBS request failed ", statusCode)
+		return cbsAPICallResult{}, err
+	}
+
+	responseError := apiResponseChecker(statusCode, response, "restoreCBS")
+	if responseError != nil {
+		return cbsAPICallResult{}, responseError
+	}
+	var result cbsAPICallResult
+	if err := json.Unmarshal(response, &result); err != nil {
+		log.Print("Failed to unmarshall response from restoreCBS ", err)
+		return cbsAPICallResult{}, err
+	}
+	log.Print("restoreCBS result:", result)
+
+	return result, nil
+}
+
+// getCBSRestoreType returns file when files are restored, volume otherwise
+func getCBSRestoreType(restore cbsRestoreRequest) string {
+	if len(restore.File) != 0 {
+		return "file"
+	}
+	return "volume"
+}
+
+// waitOnCBSResponseJob waits for the job of the response to complete, a response without a job is completed
+func (c *Client) waitOnCBSResponseJob(response []byte, cbs cbsRequest, actionName string, task string, retries int, waitInterval int, clientID string) error {
+	if len(response) == 0 {
+		return nil
+	}
+	var result cbsAPICallResult
+	if err := json.Unmarshal(response, &result); err != nil {
+		log.Printf("Failed to unmarshall response from %s %s %v", task, actionName, err)
+		return err
+	}
+	if result.ID == "" {
+		return nil
+	}
+	_, err := c.waitOnJobCompletionCBS(result.ID, cbs, actionName, task, retries, waitInterval, clientID)
+	return err
+}
+
 // Read working environment cloud backup details
 func (c *Client) getCBS(cbs cbsRequest, clientID string) (cbsWEResult, error) {
 	log.Print("getCBS...")
@@ -559,6 +851,9 @@ func (c *Client) waitOnJobCompletionCBS(id string, cbs cbsRequest, actionName st
 		if err != nil {
 			return cbsJobStatus, err
 		}
+		if len(cbsJobStatus) == 0 {
+			return cbsJobStatus, fmt.Errorf("cbs jobID %s WE %s %s %s not found", id, cbs.WorkingEnvironmentID, task, actionName)
+		}
 		if cbsJobStatus[0].JobStatus == "FAILED" {
 			return cbsJobStatus, fmt.Errorf("cbs jobID %s WE %s %s %s status FAILED: %s", id, cbs.WorkingEnvironmentID, task, actionName, cbsJobStatus[0].JobError)
 		} else if cbsJobStatus[0].JobStatus == "COMPLETED" {
@@ -0,0 +1,177 @@
+package cloudmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/fatih/structs"
+)
+
+// cifsShareRequest the users input for creating or updating a CIFS share
+type cifsShareRequest struct {
+	Name                   string         `structs:"shareName"`
+	Path                   string         `structs:"path"`
+	SvmName                string         `structs:"svmName"`
+	WorkingEnvironmentID   string         `structs:"workingEnvironmentId"`
+	Comment                string         `structs:"comment"`
+	AccessBasedEnumeration bool           `structs:"accessBasedEnumeration"`
+	Oplocks                bool           `structs:"oplocks"`
+	ContinuouslyAvailable  bool           `structs:"continuouslyAvailable"`
+	Acls                   []cifsShareACL `structs:"acls"`
+}
+
+// cifsShareACL describes one access control entry of a CIFS share
+type cifsShareACL struct {
+	UserOrGroup string `structs:"userOrGroup" json:"userOrGroup"`
+	Permission  string `structs:"permission" json:"permission"`
+	Type        string `structs:"type" json:"type"`
+}
+
+// cifsShareResponse describes a CIFS share returned by the API
+type cifsShareResponse struct {
+	Name                   string         `json:"shareName"`
+	Path                   string         `json:"path"`
+	SvmName                string         `json:"svmName"`
+	VolumeName             string         `json:"volumeName"`
+	Comment                string         `json:"comment"`
+	AccessBasedEnumeration bool           `json:"accessBasedEnumeration"`
+	Oplocks                bool           `json:"oplocks"`
+	ContinuouslyAvailable  bool           `json:"continuouslyAvailable"`
+	Acls                   []cifsShareACL `json:"acls"`
+}
+
+func (c *Client) createCIFSShare(request cifsShareRequest, clientID string, isSaas bool, connectorIP string) error {
+	log.Print("On createCIFSShare... ")
+	baseURL, _, err := c.getAPIRoot(request.WorkingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("createCIFSShare: Cannot get API root.")
+		return err
+	}
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	baseURL = fmt.Sprintf("%s/working-environments/%s/cifs-shares", baseURL, request.WorkingEnvironmentID)
+	params := structs.Map(request)
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("POST", baseURL, params, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("createCIFSShare request failed ", statusCode)
+		return err
+	}
+	responseError := apiResponseChecker(statusCode, response, "createCIFSShare")
+	if responseError != nil {
+		return responseError
+	}
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, "cifsShare", "create", 10, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "cifsShare", "create", 10, 10, clientID, connectorIP)
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// getCIFSShare returns the CIFS share with the given name on the svm. An empty name is returned if it does not exist.
+func (c *Client) getCIFSShare(workingEnvironmentID string, svmName string, name string, clientID string, isSaas bool, connectorIP string) (cifsShareResponse, error) {
+	log.Printf("getCIFSShare %s", name)
+	var result cifsShareResponse
+	baseURL, _, err := c.getAPIRoot(workingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("getCIFSShare: Cannot get API root.")
+		return result, err
+	}
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	baseURL = fmt.Sprintf("%s/working-environments/%s/cifs-shares?svm=%s", baseURL, workingEnvironmentID, svmName)
+	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("getCIFSShare request failed ", statusCode)
+		return result, err
+	}
+	responseError := apiResponseChecker(statusCode, response, "getCIFSShare")
+	if responseError != nil {
+		return result, responseError
+	}
+	var shares []cifsShareResponse
+	if err := json.Unmarshal(response, &shares); err != nil {
+		log.Print("Failed to unmarshall response from getCIFSShare ", err)
+		return result, err
+	}
+	for _, share := range shares {
+		if share.Name == name {
+			return share, nil
+		}
+	}
+	log.Printf("Cannot find CIFS share %s", name)
+	return result, nil
+}
+
+func (c *Client) updateCIFSShare(request cifsShareRequest, clientID string, isSaas bool, connectorIP string) error {
+	log.Print("On updateCIFSShare... ")
+	baseURL, _, err := c.getAPIRoot(request.WorkingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("updateCIFSShare: Cannot get API root.")
+		return err
+	}
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	baseURL = fmt.Sprintf("%s/working-environments/%s/cifs-shares/%s/%s", baseURL, request.WorkingEnvironmentID, request.SvmName, request.Name)
+	params := structs.Map(request)
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("PUT", baseURL, params, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("updateCIFSShare request failed ", statusCode)
+		return err
+	}
+	responseError := apiResponseChecker(statusCode, response, "updateCIFSShare")
+	if responseError != nil {
+		return responseError
+	}
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, "cifsShare", "update", 10, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "cifsShare", "update", 10, 10, clientID, connectorIP)
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *Client) deleteCIFSShare(workingEnvironmentID string, svmName string, name string, clientID string, isSaas bool, connectorIP string) error {
+	log.Print("On deleteCIFSShare... ")
+	baseURL, _, err := c.getAPIRoot(workingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("deleteCIFSShare: Cannot get API root.")
+		return err
+	}
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	baseURL = fmt.Sprintf("%s/working-environments/%s/cifs-shares/%s/%s", baseURL, workingEnvironmentID, svmName, name)
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("DELETE", baseURL, nil, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("deleteCIFSShare request failed ", statusCode)
+		return err
+	}
+	responseError := apiResponseChecker(statusCode, response, "deleteCIFSShare")
+	if responseError != nil {
+		return responseError
+	}
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, "cifsShare", "delete", 10, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "cifsShare", "delete", 10, 10, clientID, connectorIP)
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -61,13 +61,14 @@ type Client struct {
 	CVSHostName             string
 	Retries                 int
 
-	initOnce           sync.Once
-	restapiClient      *restapi.Client
-	requestSlots       chan int
-	Simulator          bool
-	AWSProfile         string
-	AWSProfileFilePath string
-	AzureAuthMethods   []string
+	initOnce              sync.Once
+	restapiClient         *restapi.Client
+	requestSlots          chan int
+	Simulator             bool
+	AWSProfile            string
+	AWSProfileFilePath    string
+	AzureAuthMethods      []string
+	DeleteEmptyAggregates bool
 }
 
 // CallAWSInstanceCreate can be used to make a request to create AWS Instance
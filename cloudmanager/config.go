@@ -8,16 +8,17 @@ import (
 
 // Config is a struct for user input
 type configStruct struct {
-	RefreshToken       string
-	SaSecretKey        string
-	SaClientID         string
-	Environment        string
-	CVOHostName        string
-	Simulator          bool
-	AWSProfile         string
-	AWSProfileFilePath string
-	AzureAuthMethods   []string
-	ConnectorHost      string
+	RefreshToken          string
+	SaSecretKey           string
+	SaClientID            string
+	Environment           string
+	CVOHostName           string
+	Simulator             bool
+	AWSProfile            string
+	AWSProfileFilePath    string
+	AzureAuthMethods      []string
+	ConnectorHost         string
+	DeleteEmptyAggregates bool
 }
 
 // Client is the main function to connect to the APi
@@ -101,6 +102,7 @@ func (c *configStruct) clientFun() (*Client, error) {
 	client.AWSProfile = c.AWSProfile
 	client.AWSProfileFilePath = c.AWSProfileFilePath
 	client.AzureAuthMethods = c.AzureAuthMethods
+	client.DeleteEmptyAggregates = c.DeleteEmptyAggregates
 
 	return client, nil
 }
@@ -0,0 +1,111 @@
+package cloudmanager
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func dataSourceAntiRansomwareStatus() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAntiRansomwareStatusRead,
+		Schema: map[string]*schema.Schema{
+			"volume_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"svm_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"connector_ip": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"deployment_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"Standard", "Restricted"}, false),
+				Default:      "Standard",
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"attack_probability": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"attack_detected": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"attack_detected_by": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"suspect_files_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"dry_run_start_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAntiRansomwareStatusRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Fetching data source anti-ransomware status: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+	svm := getSvmName(d, workingEnv)
+	volumeName := d.Get("volume_name").(string)
+
+	status, err := client.getVolumeAntiRansomwareStatus(workingEnv.PublicID, svm, volumeName, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error reading anti-ransomware status")
+		return err
+	}
+
+	d.SetId(workingEnv.PublicID + ":" + svm + ":" + volumeName)
+	d.Set("svm_name", svm)
+	d.Set("state", status.State)
+	d.Set("attack_probability", status.AttackProbability)
+	d.Set("attack_detected", status.AttackProbability != "" && status.AttackProbability != "none")
+	d.Set("attack_detected_by", status.AttackDetectedBy)
+	d.Set("suspect_files_count", status.SuspectFilesCount)
+	d.Set("dry_run_start_time", status.DryRunStartTime)
+
+	return nil
+}
@@ -220,7 +220,11 @@ func dataSourceCVOVolumeRead(d *schema.ResourceData, meta interface{}) error {
 			if err != nil {
 				return fmt.Errorf("error setting tiering_policy: %s", err.Error())
 			}
-			err = d.Set("size", convertSizeUnit(volume.Size.Size, volume.Size.Unit, "GB"))
+			size, err := convertSizeUnit(volume.Size.Size, volume.Size.Unit, "GB")
+			if err != nil {
+				return fmt.Errorf("error reading size: %s", err.Error())
+			}
+			err = d.Set("size", size)
 			if err != nil {
 				return fmt.Errorf("error setting size: %s", err.Error())
 			}
@@ -0,0 +1,164 @@
+package cloudmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/fatih/structs"
+)
+
+// exportPolicyRequest the users input for creating or updating an export policy
+type exportPolicyRequest struct {
+	Name                 string             `structs:"name"`
+	SvmName              string             `structs:"svmName"`
+	WorkingEnvironmentID string             `structs:"workingEnvironmentId"`
+	Rules                []ExportPolicyRule `structs:"rules"`
+}
+
+// exportPolicyResponse describes an export policy returned by the API
+type exportPolicyResponse struct {
+	Name    string             `json:"name"`
+	SvmName string             `json:"svmName"`
+	Rules   []ExportPolicyRule `json:"rules"`
+}
+
+func (c *Client) createExportPolicy(request exportPolicyRequest, clientID string, isSaas bool, connectorIP string) error {
+	log.Print("On createExportPolicy... ")
+	baseURL, _, err := c.getAPIRoot(request.WorkingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("createExportPolicy: Cannot get API root.")
+		return err
+	}
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	baseURL = fmt.Sprintf("%s/working-environments/%s/export-policies", baseURL, request.WorkingEnvironmentID)
+	params := structs.Map(request)
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("POST", baseURL, params, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("createExportPolicy request failed ", statusCode)
+		return err
+	}
+	responseError := apiResponseChecker(statusCode, response, "createExportPolicy")
+	if responseError != nil {
+		return responseError
+	}
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, "exportPolicy", "create", 10, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "exportPolicy", "create", 10, 10, clientID, connectorIP)
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// getExportPolicy returns the export policy with the given name on the svm. An empty name is returned if it does not exist.
+func (c *Client) getExportPolicy(workingEnvironmentID string, svmName string, name string, clientID string, isSaas bool, connectorIP string) (exportPolicyResponse, error) {
+	log.Printf("getExportPolicy %s", name)
+	var result exportPolicyResponse
+	baseURL, _, err := c.getAPIRoot(workingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("getExportPolicy: Cannot get API root.")
+		return result, err
+	}
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	baseURL = fmt.Sprintf("%s/working-environments/%s/export-policies?svm=%s", baseURL, workingEnvironmentID, svmName)
+	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("getExportPolicy request failed ", statusCode)
+		return result, err
+	}
+	responseError := apiResponseChecker(statusCode, response, "getExportPolicy")
+	if responseError != nil {
+		return result, responseError
+	}
+	var policies []exportPolicyResponse
+	if err := json.Unmarshal(response, &policies); err != nil {
+		log.Print("Failed to unmarshall response from getExportPolicy ", err)
+		return result, err
+	}
+	for _, policy := range policies {
+		if policy.Name == name {
+			// rules are evaluated in index order, keep them that way
+			sort.Slice(policy.Rules, func(i, j int) bool {
+				return policy.Rules[i].Index < policy.Rules[j].Index
+			})
+			return policy, nil
+		}
+	}
+	log.Printf("Cannot find export policy %s", name)
+	return result, nil
+}
+
+func (c *Client) updateExportPolicy(request exportPolicyRequest, clientID string, isSaas bool, connectorIP string) error {
+	log.Print("On updateExportPolicy... ")
+	baseURL, _, err := c.getAPIRoot(request.WorkingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("updateExportPolicy: Cannot get API root.")
+		return err
+	}
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	baseURL = fmt.Sprintf("%s/working-environments/%s/export-policies/%s/%s", baseURL, request.WorkingEnvironmentID, request.SvmName, request.Name)
+	params := structs.Map(request)
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("PUT", baseURL, params, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("updateExportPolicy request failed ", statusCode)
+		return err
+	}
+	responseError := apiResponseChecker(statusCode, response, "updateExportPolicy")
+	if responseError != nil {
+		return responseError
+	}
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, "exportPolicy", "update", 10, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "exportPolicy", "update", 10, 10, clientID, connectorIP)
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *Client) deleteExportPolicy(workingEnvironmentID string, svmName string, name string, clientID string, isSaas bool, connectorIP string) error {
+	log.Print("On deleteExportPolicy... ")
+	baseURL, _, err := c.getAPIRoot(workingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("deleteExportPolicy: Cannot get API root.")
+		return err
+	}
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	baseURL = fmt.Sprintf("%s/working-environments/%s/export-policies/%s/%s", baseURL, workingEnvironmentID, svmName, name)
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("DELETE", baseURL, nil, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("deleteExportPolicy request failed ", statusCode)
+		return err
+	}
+	responseError := apiResponseChecker(statusCode, response, "deleteExportPolicy")
+	if responseError != nil {
+		return responseError
+	}
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, "exportPolicy", "delete", 10, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "exportPolicy", "delete", 10, 10, clientID, connectorIP)
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
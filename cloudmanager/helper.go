@@ -934,6 +934,7 @@ func (c *Client) getWorkingEnvironmentDetailForSnapMirror(d *schema.ResourceData
 					return workingEnvironmentInfo{}, workingEnvironmentInfo{}, err
 				}
 				sourceWorkingEnvDetail.SvmName = svmName
+				sourceWorkingEnvDetail.WorkingEnvironmentType = "FSX"
 			} else {
 				return workingEnvironmentInfo{}, workingEnvironmentInfo{}, fmt.Errorf("cannot find FSX working environment by destination_working_environment_id %s, need tenant_id", WorkingEnvironmentID)
 			}
@@ -961,6 +962,7 @@ func (c *Client) getWorkingEnvironmentDetailForSnapMirror(d *schema.ResourceData
 						return workingEnvironmentInfo{}, workingEnvironmentInfo{}, err
 					}
 					sourceWorkingEnvDetail.SvmName = svmName
+					sourceWorkingEnvDetail.WorkingEnvironmentType = "FSX"
 				}
 			}
 		}
@@ -993,6 +995,7 @@ func (c *Client) getWorkingEnvironmentDetailForSnapMirror(d *schema.ResourceData
 					return workingEnvironmentInfo{}, workingEnvironmentInfo{}, err
 				}
 				destWorkingEnvDetail.SvmName = svmName
+				destWorkingEnvDetail.WorkingEnvironmentType = "FSX"
 			} else {
 				return workingEnvironmentInfo{}, workingEnvironmentInfo{}, fmt.Errorf("cannot find FSX working environment by destination_working_environment_id %s, need tenant_id", WorkingEnvironmentID)
 			}
@@ -1015,13 +1018,14 @@ func (c *Client) getWorkingEnvironmentDetailForSnapMirror(d *schema.ResourceData
 					log.Print("Error getting AWS FSX: ", err)
 					return workingEnvironmentInfo{}, workingEnvironmentInfo{}, err
 				}
-				if destWorkingEnvDetail.PublicID != "" {
+				if WorkingEnvironmentID != "" {
 					destWorkingEnvDetail.PublicID = WorkingEnvironmentID
 					svmName, err := c.getFSXSVM(WorkingEnvironmentID, clientID, isSaas, connectorIP)
 					if err != nil {
 						return workingEnvironmentInfo{}, workingEnvironmentInfo{}, err
 					}
 					destWorkingEnvDetail.SvmName = svmName
+					destWorkingEnvDetail.WorkingEnvironmentType = "FSX"
 				}
 			}
 		}
@@ -1836,3 +1840,14 @@ func (c *Client) checkAndDoUpgradeOntapVersion(d *schema.ResourceData, clientID
 	}
 	return nil
 }
+
+// getSvmName returns svm_name if set, otherwise the default svm of the working environment.
+func getSvmName(d *schema.ResourceData, workingEnv workingEnvironmentInfo) string {
+	if v, ok := d.GetOk("svm_name"); ok {
+		return v.(string)
+	}
+	if workingEnv.SvmName != "" {
+		return workingEnv.SvmName
+	}
+	return "svm_" + workingEnv.Name
+}
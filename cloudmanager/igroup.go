@@ -0,0 +1,272 @@
+package cloudmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/fatih/structs"
+)
+
+// igroupRequest the users input for creating or updating an igroup
+type igroupRequest struct {
+	IgroupName           string   `structs:"igroupName"`
+	OsType               string   `structs:"osType"`
+	IgroupType           string   `structs:"igroupType"`
+	Portsets             []string `structs:"portsets,omitempty"`
+	Initiators           []string `structs:"initiators"`
+	WorkingEnvironmentID string   `structs:"workingEnvironmentId"`
+	SvmName              string   `structs:"svmName"`
+}
+
+// lunMapRequest the users input for mapping the LUN of a volume to an igroup
+type lunMapRequest struct {
+	IgroupName           string `structs:"igroupName"`
+	LunID                int    `structs:"lunId,omitempty"`
+	WorkingEnvironmentID string `structs:"workingEnvironmentId"`
+	SvmName              string `structs:"svmName"`
+	VolumeName           string `structs:"volumeName"`
+}
+
+// lunMapResponse describes a LUN mapping returned by the API
+type lunMapResponse struct {
+	IgroupName string `json:"igroupName"`
+	LunID      int    `json:"lunId"`
+	LunPath    string `json:"lunPath"`
+}
+
+func (c *Client) createIgroup(request igroupRequest, clientID string, isSaas bool, connectorIP string) error {
+	log.Print("On createIgroup... ")
+	baseURL, _, err := c.getAPIRoot(request.WorkingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("createIgroup: Cannot get API root.")
+		return err
+	}
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	baseURL = fmt.Sprintf("%s/volumes/igroups/%s/%s", baseURL, request.WorkingEnvironmentID, request.SvmName)
+	params := structs.Map(request)
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("POST", baseURL, params, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("createIgroup request failed ", statusCode)
+		return err
+	}
+	responseError := apiResponseChecker(statusCode, response, "createIgroup")
+	if responseError != nil {
+		return responseError
+	}
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, "igroup", "create", 10, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "igroup", "create", 10, 10, clientID, connectorIP)
+	}
+	return err
+}
+
+// getIgroup returns the igroup with the given name in the svm. An empty name is returned if it does not exist.
+func (c *Client) getIgroup(workingEnvironmentID string, workingEnvironmentType string, svmName string, name string, clientID string, isSaas bool, connectorIP string) (igroup, error) {
+	log.Printf("getIgroup %s", name)
+	var result igroup
+	request := igroup{}
+	request.WorkingEnvironmentID = workingEnvironmentID
+	request.WorkingEnvironmentType = workingEnvironmentType
+	request.SvmName = svmName
+	igroups, err := c.getIgroups(request, clientID, isSaas, connectorIP)
+	if err != nil {
+		return result, err
+	}
+	for _, ig := range igroups {
+		if ig.IgroupName == name {
+			return ig, nil
+		}
+	}
+	log.Printf("Cannot find igroup %s", name)
+	return result, nil
+}
+
+func (c *Client) updateIgroup(request igroupRequest, clientID string, isSaas bool, connectorIP string) error {
+	log.Print("On updateIgroup... ")
+	baseURL, _, err := c.getAPIRoot(request.WorkingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("updateIgroup: Cannot get API root.")
+		return err
+	}
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	baseURL = fmt.Sprintf("%s/volumes/igroups/%s/%s/%s", baseURL, request.WorkingEnvironmentID, request.SvmName, request.IgroupName)
+	params := structs.Map(request)
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("PUT", baseURL, params, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("updateIgroup request failed ", statusCode)
+		return err
+	}
+	responseError := apiResponseChecker(statusCode, response, "updateIgroup")
+	if responseError != nil {
+		return responseError
+	}
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, "igroup", "update", 10, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "igroup", "update", 10, 10, clientID, connectorIP)
+	}
+	return err
+}
+
+func (c *Client) deleteIgroup(workingEnvironmentID string, svmName string, name string, clientID string, isSaas bool, connectorIP string) error {
+	log.Print("On deleteIgroup... ")
+	baseURL, _, err := c.getAPIRoot(workingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("deleteIgroup: Cannot get API root.")
+		return err
+	}
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	baseURL = fmt.Sprintf("%s/volumes/igroups/%s/%s/%s", baseURL, workingEnvironmentID, svmName, name)
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("DELETE", baseURL, nil, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("deleteIgroup request failed ", statusCode)
+		return err
+	}
+	responseError := apiResponseChecker(statusCode, response, "deleteIgroup")
+	if responseError != nil {
+		return responseError
+	}
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, "igroup", "delete", 10, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "igroup", "delete", 10, 10, clientID, connectorIP)
+	}
+	return err
+}
+
+// ensureInitiators creates the initiators which are not yet known in the working environment
+func (c *Client) ensureInitiators(initiators []initiator, workingEnvironmentID string, workingEnvironmentType string, svmName string, clientID string, isSaas bool, connectorIP string) error {
+	getAll := initiator{}
+	getAll.WorkingEnvironmentID = workingEnvironmentID
+	getAll.WorkingEnvironmentType = workingEnvironmentType
+	res, err := c.getInitiator(getAll, clientID, isSaas, connectorIP)
+	if err != nil {
+		return err
+	}
+	for _, expectIni := range initiators {
+		found := false
+		for _, currentIni := range res {
+			if expectIni.Iqn == currentIni.Iqn {
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		expectIni.WorkingEnvironmentID = workingEnvironmentID
+		expectIni.WorkingEnvironmentType = workingEnvironmentType
+		expectIni.SvmName = svmName
+		if err := c.createInitiator(expectIni, clientID, isSaas, connectorIP); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) createLunMap(request lunMapRequest, clientID string, isSaas bool, connectorIP string) error {
+	log.Print("On createLunMap... ")
+	baseURL, _, err := c.getAPIRoot(request.WorkingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("createLunMap: Cannot get API root.")
+		return err
+	}
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	baseURL = fmt.Sprintf("%s/volumes/%s/%s/%s/lun-maps", baseURL, request.WorkingEnvironmentID, request.SvmName, request.VolumeName)
+	params := structs.Map(request)
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("POST", baseURL, params, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("createLunMap request failed ", statusCode)
+		return err
+	}
+	responseError := apiResponseChecker(statusCode, response, "createLunMap")
+	if responseError != nil {
+		return responseError
+	}
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, "lun map", "create", 10, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "lun map", "create", 10, 10, clientID, connectorIP)
+	}
+	return err
+}
+
+// getLunMap returns the mapping of the volume LUN to the igroup. An empty igroup name is returned if it does not exist.
+func (c *Client) getLunMap(workingEnvironmentID string, svmName string, volumeName string, igroupName string, clientID string, isSaas bool, connectorIP string) (lunMapResponse, error) {
+	log.Printf("getLunMap %s %s", volumeName, igroupName)
+	var result lunMapResponse
+	baseURL, _, err := c.getAPIRoot(workingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("getLunMap: Cannot get API root.")
+		return result, err
+	}
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	baseURL = fmt.Sprintf("%s/volumes/%s/%s/%s/lun-maps", baseURL, workingEnvironmentID, svmName, volumeName)
+	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("getLunMap request failed ", statusCode)
+		return result, err
+	}
+	responseError := apiResponseChecker(statusCode, response, "getLunMap")
+	if responseError != nil {
+		return result, responseError
+	}
+	var lunMaps []lunMapResponse
+	if err := json.Unmarshal(response, &lunMaps); err != nil {
+		log.Print("Failed to unmarshall response from getLunMap ", err)
+		return result, err
+	}
+	for _, lunMap := range lunMaps {
+		if lunMap.IgroupName == igroupName {
+			return lunMap, nil
+		}
+	}
+	log.Printf("Cannot find lun map of volume %s to igroup %s", volumeName, igroupName)
+	return result, nil
+}
+
+func (c *Client) deleteLunMap(workingEnvironmentID string, svmName string, volumeName string, igroupName string, clientID string, isSaas bool, connectorIP string) error {
+	log.Print("On deleteLunMap... ")
+	baseURL, _, err := c.getAPIRoot(workingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("deleteLunMap: Cannot get API root.")
+		return err
+	}
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	baseURL = fmt.Sprintf("%s/volumes/%s/%s/%s/lun-maps/%s", baseURL, workingEnvironmentID, svmName, volumeName, igroupName)
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("DELETE", baseURL, nil, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("deleteLunMap request failed ", statusCode)
+		return err
+	}
+	responseError := apiResponseChecker(statusCode, response, "deleteLunMap")
+	if responseError != nil {
+		return responseError
+	}
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, "lun map", "delete", 10, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "lun map", "delete", 10, 10, clientID, connectorIP)
+	}
+	return err
+}
@@ -0,0 +1,255 @@
+package cloudmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/fatih/structs"
+)
+
+// clusterPeerRequest the users input for peering the clusters of two working environments
+type clusterPeerRequest struct {
+	WorkingEnvironmentID     string   `structs:"workingEnvironmentId"`
+	PeerWorkingEnvironmentID string   `structs:"peerWorkingEnvironmentId"`
+	InterclusterLifIps       []string `structs:"interClusterLifIps"`
+	PeerInterclusterLifIps   []string `structs:"peerInterClusterLifIps"`
+}
+
+// clusterPeerResponse describes a cluster peer of a working environment
+type clusterPeerResponse struct {
+	PeerClusterName          string   `json:"peerClusterName"`
+	PeerWorkingEnvironmentID string   `json:"peerWorkingEnvironmentId"`
+	Availability             string   `json:"availability"`
+	PeerAddresses            []string `json:"peerAddresses"`
+}
+
+// svmPeerRequest the users input for peering two SVMs
+type svmPeerRequest struct {
+	WorkingEnvironmentID     string   `structs:"workingEnvironmentId"`
+	SvmName                  string   `structs:"svmName"`
+	PeerWorkingEnvironmentID string   `structs:"peerWorkingEnvironmentId"`
+	PeerSvmName              string   `structs:"peerSvmName"`
+	Applications             []string `structs:"applications"`
+}
+
+// svmPeerResponse describes a SVM peer of a working environment
+type svmPeerResponse struct {
+	SvmName                  string `json:"svmName"`
+	PeerSvmName              string `json:"peerSvmName"`
+	PeerWorkingEnvironmentID string `json:"peerWorkingEnvironmentId"`
+	State                    string `json:"state"`
+}
+
+// getInterclusterLifIps returns the first intercluster LIF address of both clusters
+func getInterclusterLifIps(lifs interclusterlif, sourceWorkingEnvironmentID string, destinationWorkingEnvironmentID string) (string, string, error) {
+	if len(lifs.Interclusterlif) == 0 {
+		return "", "", fmt.Errorf("no intercluster LIF found on working environment %s, an intercluster LIF is required for peering", sourceWorkingEnvironmentID)
+	}
+	if len(lifs.PeerInterclusterlif) == 0 {
+		return "", "", fmt.Errorf("no intercluster LIF found on working environment %s, an intercluster LIF is required for peering", destinationWorkingEnvironmentID)
+	}
+	return lifs.Interclusterlif[0].Address, lifs.PeerInterclusterlif[0].Address, nil
+}
+
+func (c *Client) createClusterPeer(request clusterPeerRequest, clientID string, isSaas bool, connectorIP string) error {
+	log.Print("On createClusterPeer... ")
+	accessTokenResult, err := c.getAccessToken()
+	if err != nil {
+		log.Print("in createClusterPeer request, failed to get AccessToken")
+		return err
+	}
+	c.Token = accessTokenResult.Token
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("POST", "/occm/api/replication/cluster-peers", structs.Map(request), c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("createClusterPeer request failed ", statusCode)
+		return err
+	}
+	responseError := apiResponseChecker(statusCode, response, "createClusterPeer")
+	if responseError != nil {
+		return responseError
+	}
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, "cluster peer", "create", 10, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "cluster peer", "create", 10, 10, clientID, connectorIP)
+	}
+	return err
+}
+
+// getClusterPeer returns the cluster peer of the working environment to the peer working environment.
+// An empty peer cluster name is returned if the clusters are not peered.
+func (c *Client) getClusterPeer(workingEnvironmentID string, peerWorkingEnvironmentID string, clientID string, isSaas bool, connectorIP string) (clusterPeerResponse, error) {
+	log.Printf("getClusterPeer %s %s", workingEnvironmentID, peerWorkingEnvironmentID)
+	var result clusterPeerResponse
+	accessTokenResult, err := c.getAccessToken()
+	if err != nil {
+		log.Print("in getClusterPeer request, failed to get AccessToken")
+		return result, err
+	}
+	c.Token = accessTokenResult.Token
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	baseURL := fmt.Sprintf("/occm/api/replication/cluster-peers/%s", workingEnvironmentID)
+	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("getClusterPeer request failed ", statusCode)
+		return result, err
+	}
+	responseError := apiResponseChecker(statusCode, response, "getClusterPeer")
+	if responseError != nil {
+		return result, responseError
+	}
+	var peers []clusterPeerResponse
+	if err := json.Unmarshal(response, &peers); err != nil {
+		log.Print("Failed to unmarshall response from getClusterPeer ", err)
+		return result, err
+	}
+	for _, peer := range peers {
+		if peer.PeerWorkingEnvironmentID == peerWorkingEnvironmentID {
+			return peer, nil
+		}
+	}
+	return result, nil
+}
+
+func (c *Client) deleteClusterPeer(workingEnvironmentID string, peerClusterName string, clientID string, isSaas bool, connectorIP string) error {
+	log.Print("On deleteClusterPeer... ")
+	accessTokenResult, err := c.getAccessToken()
+	if err != nil {
+		log.Print("in deleteClusterPeer request, failed to get AccessToken")
+		return err
+	}
+	c.Token = accessTokenResult.Token
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	baseURL := fmt.Sprintf("/occm/api/replication/cluster-peers/%s/%s", workingEnvironmentID, peerClusterName)
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("DELETE", baseURL, nil, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("deleteClusterPeer request failed ", statusCode)
+		return err
+	}
+	responseError := apiResponseChecker(statusCode, response, "deleteClusterPeer")
+	if responseError != nil {
+		return responseError
+	}
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, "cluster peer", "delete", 10, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "cluster peer", "delete", 10, 10, clientID, connectorIP)
+	}
+	return err
+}
+
+func (c *Client) createSvmPeer(request svmPeerRequest, clientID string, isSaas bool, connectorIP string) error {
+	log.Print("On createSvmPeer... ")
+	accessTokenResult, err := c.getAccessToken()
+	if err != nil {
+		log.Print("in createSvmPeer request, failed to get AccessToken")
+		return err
+	}
+	c.Token = accessTokenResult.Token
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("POST", "/occm/api/replication/svm-peers", structs.Map(request), c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("createSvmPeer request failed ", statusCode)
+		return err
+	}
+	responseError := apiResponseChecker(statusCode, response, "createSvmPeer")
+	if responseError != nil {
+		return responseError
+	}
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, "svm peer", "create", 10, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "svm peer", "create", 10, 10, clientID, connectorIP)
+	}
+	return err
+}
+
+// getSvmPeer returns the peer of the SVM to the peer SVM of the peer working environment.
+// An empty SVM name is returned if the SVMs are not peered.
+func (c *Client) getSvmPeer(workingEnvironmentID string, svmName string, peerWorkingEnvironmentID string, peerSvmName string, clientID string, isSaas bool, connectorIP string) (svmPeerResponse, error) {
+	log.Printf("getSvmPeer %s %s", svmName, peerSvmName)
+	var result svmPeerResponse
+	accessTokenResult, err := c.getAccessToken()
+	if err != nil {
+		log.Print("in getSvmPeer request, failed to get AccessToken")
+		return result, err
+	}
+	c.Token = accessTokenResult.Token
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	baseURL := fmt.Sprintf("/occm/api/replication/svm-peers/%s", workingEnvironmentID)
+	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("getSvmPeer request failed ", statusCode)
+		return result, err
+	}
+	responseError := apiResponseChecker(statusCode, response, "getSvmPeer")
+	if responseError != nil {
+		return result, responseError
+	}
+	var peers []svmPeerResponse
+	if err := json.Unmarshal(response, &peers); err != nil {
+		log.Print("Failed to unmarshall response from getSvmPeer ", err)
+		return result, err
+	}
+	for _, peer := range peers {
+		if peer.SvmName == svmName && peer.PeerSvmName == peerSvmName && peer.PeerWorkingEnvironmentID == peerWorkingEnvironmentID {
+			return peer, nil
+		}
+	}
+	return result, nil
+}
+
+func (c *Client) deleteSvmPeer(workingEnvironmentID string, svmName string, peerSvmName string, clientID string, isSaas bool, connectorIP string) error {
+	log.Print("On deleteSvmPeer... ")
+	accessTokenResult, err := c.getAccessToken()
+	if err != nil {
+		log.Print("in deleteSvmPeer request, failed to get AccessToken")
+		return err
+	}
+	c.Token = accessTokenResult.Token
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	baseURL := fmt.Sprintf("/occm/api/replication/svm-peers/%s/%s/%s", workingEnvironmentID, svmName, peerSvmName)
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("DELETE", baseURL, nil, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("deleteSvmPeer request failed ", statusCode)
+		return err
+	}
+	responseError := apiResponseChecker(statusCode, response, "deleteSvmPeer")
+	if responseError != nil {
+		return responseError
+	}
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, "svm peer", "delete", 10, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "svm peer", "delete", 10, 10, clientID, connectorIP)
+	}
+	return err
+}
+
+func expandInterclusterLifIps(values []interface{}) []string {
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		result = append(result, v.(string))
+	}
+	return result
+}
@@ -67,33 +67,54 @@ func Provider() terraform.ResourceProvider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("AZURE_AUTH_METHODS", nil),
 			},
+			"delete_empty_aggregates": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Delete the aggregates created for a volume when their last volume is deleted.",
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"netapp-cloudmanager_connector_aws":   resourceOCCMAWS(),
-			"netapp-cloudmanager_connector_azure": resourceOCCMAzure(),
-			"netapp-cloudmanager_connector_gcp":   resourceOCCMGCP(),
-			"netapp-cloudmanager_cvo_aws":         resourceCVOAWS(),
-			"netapp-cloudmanager_cvo_azure":       resourceCVOAzure(),
-			"netapp-cloudmanager_cvo_gcp":         resourceCVOGCP(),
-			"netapp-cloudmanager_aggregate":       resourceAggregate(),
-			"netapp-cloudmanager_volume":          resourceCVOVolume(),
-			"netapp-cloudmanager_cifs_server":     resourceCVOCIFS(),
-			"netapp-cloudmanager_snapmirror":      resourceCVOSnapMirror(),
-			"netapp-cloudmanager_nss_account":     resourceCVONssAccount(),
-			"netapp-cloudmanager_anf_volume":      resourceCVSANFVolume(),
-			"netapp-cloudmanager_cvs_gcp_volume":  resourceCVSGCPVolume(),
-			"netapp-cloudmanager_aws_fsx":         resourceAWSFSX(),
-			"netapp-cloudmanager_aws_fsx_volume":  resourceFsxVolume(),
-			"netapp-cloudmanager_cvo_onprem":      resourceCVOOnPrem(),
-			"netapp-cloudmanager_cbs":             resourceCBS(),
+			"netapp-cloudmanager_connector_aws":     resourceOCCMAWS(),
+			"netapp-cloudmanager_connector_azure":   resourceOCCMAzure(),
+			"netapp-cloudmanager_connector_gcp":     resourceOCCMGCP(),
+			"netapp-cloudmanager_cvo_aws":           resourceCVOAWS(),
+			"netapp-cloudmanager_cvo_azure":         resourceCVOAzure(),
+			"netapp-cloudmanager_cvo_gcp":           resourceCVOGCP(),
+			"netapp-cloudmanager_aggregate":         resourceAggregate(),
+			"netapp-cloudmanager_volume":            resourceCVOVolume(),
+			"netapp-cloudmanager_cifs_server":       resourceCVOCIFS(),
+			"netapp-cloudmanager_snapmirror":        resourceCVOSnapMirror(),
+			"netapp-cloudmanager_snapmirror_policy": resourceSnapMirrorPolicy(),
+			"netapp-cloudmanager_cron_schedule":     resourceCronSchedule(),
+			"netapp-cloudmanager_cluster_peer":      resourceClusterPeer(),
+			"netapp-cloudmanager_svm_peer":          resourceSvmPeer(),
+			"netapp-cloudmanager_nss_account":       resourceCVONssAccount(),
+			"netapp-cloudmanager_anf_volume":        resourceCVSANFVolume(),
+			"netapp-cloudmanager_cvs_gcp_volume":    resourceCVSGCPVolume(),
+			"netapp-cloudmanager_aws_fsx":           resourceAWSFSX(),
+			"netapp-cloudmanager_aws_fsx_volume":    resourceFsxVolume(),
+			"netapp-cloudmanager_cvo_onprem":        resourceCVOOnPrem(),
+			"netapp-cloudmanager_cbs":               resourceCBS(),
+			"netapp-cloudmanager_backup_policy":     resourceBackupPolicy(),
+			"netapp-cloudmanager_volume_backup":     resourceVolumeBackup(),
+			"netapp-cloudmanager_backup_restore":    resourceBackupRestore(),
+			"netapp-cloudmanager_export_policy":     resourceExportPolicy(),
+			"netapp-cloudmanager_cifs_share":        resourceCIFSShare(),
+			"netapp-cloudmanager_qtree":             resourceQtree(),
+			"netapp-cloudmanager_quota_rule":        resourceQuotaRule(),
+			"netapp-cloudmanager_igroup":            resourceIgroup(),
+			"netapp-cloudmanager_lun_map":           resourceLunMap(),
+			"netapp-cloudmanager_snapshot_policy":   resourceSnapshotPolicy(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"netapp-cloudmanager_cifs_server": dataSourceCVOCIFS(),
-			"netapp-cloudmanager_volume":      dataSourceCVOVolume(),
-			"netapp-cloudmanager_nss_account": dataSourceCVONssAccount(),
-			"netapp-cloudmanager_aws_fsx":     dataSourceAWSFSX(),
-			"netapp-cloudmanager_cvo_aws":     dataSourceCVOAWS(),
+			"netapp-cloudmanager_cifs_server":            dataSourceCVOCIFS(),
+			"netapp-cloudmanager_volume":                 dataSourceCVOVolume(),
+			"netapp-cloudmanager_nss_account":            dataSourceCVONssAccount(),
+			"netapp-cloudmanager_aws_fsx":                dataSourceAWSFSX(),
+			"netapp-cloudmanager_cvo_aws":                dataSourceCVOAWS(),
+			"netapp-cloudmanager_anti_ransomware_status": dataSourceAntiRansomwareStatus(),
 		},
 
 		ConfigureFunc: providerConfigure,
@@ -102,11 +123,12 @@ func Provider() terraform.ResourceProvider {
 
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	config := configStruct{
-		RefreshToken: d.Get("refresh_token").(string),
-		Environment:  d.Get("environment").(string),
-		SaSecretKey:  d.Get("sa_secret_key").(string),
-		SaClientID:   d.Get("sa_client_id").(string),
-		Simulator:    d.Get("simulator").(bool),
+		RefreshToken:          d.Get("refresh_token").(string),
+		Environment:           d.Get("environment").(string),
+		SaSecretKey:           d.Get("sa_secret_key").(string),
+		SaClientID:            d.Get("sa_client_id").(string),
+		Simulator:             d.Get("simulator").(bool),
+		DeleteEmptyAggregates: d.Get("delete_empty_aggregates").(bool),
 	}
 
 	if v, ok := d.GetOk("aws_profile"); ok {
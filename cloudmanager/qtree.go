@@ -0,0 +1,164 @@
+package cloudmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/fatih/structs"
+)
+
+// qtreeRequest the users input for creating or updating a qtree
+type qtreeRequest struct {
+	Name                 string `structs:"name"`
+	WorkingEnvironmentID string `structs:"workingEnvironmentId"`
+	SvmName              string `structs:"svmName"`
+	VolumeName           string `structs:"volumeName"`
+	SecurityStyle        string `structs:"securityStyle,omitempty"`
+	ExportPolicyName     string `structs:"exportPolicyName,omitempty"`
+	Oplocks              bool   `structs:"oplocks"`
+}
+
+// qtreeResponse describes a qtree returned by the API
+type qtreeResponse struct {
+	Name             string `json:"name"`
+	VolumeName       string `json:"volumeName"`
+	SecurityStyle    string `json:"securityStyle"`
+	ExportPolicyName string `json:"exportPolicyName"`
+	Oplocks          bool   `json:"oplocks"`
+}
+
+func (c *Client) createQtree(request qtreeRequest, clientID string, isSaas bool, connectorIP string) error {
+	log.Print("On createQtree... ")
+	baseURL, _, err := c.getAPIRoot(request.WorkingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("createQtree: Cannot get API root.")
+		return err
+	}
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	baseURL = fmt.Sprintf("%s/volumes/%s/%s/%s/qtrees", baseURL, request.WorkingEnvironmentID, request.SvmName, request.VolumeName)
+	params := structs.Map(request)
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("POST", baseURL, params, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("createQtree request failed ", statusCode)
+		return err
+	}
+	responseError := apiResponseChecker(statusCode, response, "createQtree")
+	if responseError != nil {
+		return responseError
+	}
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, "qtree", "create", 10, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "qtree", "create", 10, 10, clientID, connectorIP)
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// getQtree returns the qtree with the given name in the volume. An empty name is returned if it does not exist.
+func (c *Client) getQtree(workingEnvironmentID string, svmName string, volumeName string, name string, clientID string, isSaas bool, connectorIP string) (qtreeResponse, error) {
+	log.Printf("getQtree %s", name)
+	var result qtreeResponse
+	baseURL, _, err := c.getAPIRoot(workingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("getQtree: Cannot get API root.")
+		return result, err
+	}
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	baseURL = fmt.Sprintf("%s/volumes/%s/%s/%s/qtrees", baseURL, workingEnvironmentID, svmName, volumeName)
+	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("getQtree request failed ", statusCode)
+		return result, err
+	}
+	responseError := apiResponseChecker(statusCode, response, "getQtree")
+	if responseError != nil {
+		return result, responseError
+	}
+	var qtrees []qtreeResponse
+	if err := json.Unmarshal(response, &qtrees); err != nil {
+		log.Print("Failed to unmarshall response from getQtree ", err)
+		return result, err
+	}
+	for _, qtree := range qtrees {
+		if qtree.Name == name {
+			return qtree, nil
+		}
+	}
+	log.Printf("Cannot find qtree %s", name)
+	return result, nil
+}
+
+func (c *Client) updateQtree(request qtreeRequest, clientID string, isSaas bool, connectorIP string) error {
+	log.Print("On updateQtree... ")
+	baseURL, _, err := c.getAPIRoot(request.WorkingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("updateQtree: Cannot get API root.")
+		return err
+	}
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	baseURL = fmt.Sprintf("%s/volumes/%s/%s/%s/qtrees/%s", baseURL, request.WorkingEnvironmentID, request.SvmName, request.VolumeName, request.Name)
+	params := structs.Map(request)
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("PUT", baseURL, params, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("updateQtree request failed ", statusCode)
+		return err
+	}
+	responseError := apiResponseChecker(statusCode, response, "updateQtree")
+	if responseError != nil {
+		return responseError
+	}
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, "qtree", "update", 10, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "qtree", "update", 10, 10, clientID, connectorIP)
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *Client) deleteQtree(workingEnvironmentID string, svmName string, volumeName string, name string, clientID string, isSaas bool, connectorIP string) error {
+	log.Print("On deleteQtree... ")
+	baseURL, _, err := c.getAPIRoot(workingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("deleteQtree: Cannot get API root.")
+		return err
+	}
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	baseURL = fmt.Sprintf("%s/volumes/%s/%s/%s/qtrees/%s", baseURL, workingEnvironmentID, svmName, volumeName, name)
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("DELETE", baseURL, nil, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("deleteQtree request failed ", statusCode)
+		return err
+	}
+	responseError := apiResponseChecker(statusCode, response, "deleteQtree")
+	if responseError != nil {
+		return responseError
+	}
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, "qtree", "delete", 10, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "qtree", "delete", 10, 10, clientID, connectorIP)
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package cloudmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+
+	"github.com/fatih/structs"
+)
+
+// quotaRuleRequest the users input for creating or updating a quota rule
+type quotaRuleRequest struct {
+	WorkingEnvironmentID string `structs:"workingEnvironmentId"`
+	SvmName              string `structs:"svmName"`
+	VolumeName           string `structs:"volumeName"`
+	Type                 string `structs:"type"`
+	QtreeName            string `structs:"qtreeName"`
+	UserOrGroup          string `structs:"target"`
+	DiskLimit            size   `structs:"diskLimit"`
+	SoftDiskLimit        size   `structs:"softDiskLimit"`
+	FileLimit            int    `structs:"fileLimit"`
+	SoftFileLimit        int    `structs:"softFileLimit"`
+}
+
+// quotaRuleResponse describes a quota rule returned by the API
+type quotaRuleResponse struct {
+	Type          string   `json:"type"`
+	QtreeName     string   `json:"qtreeName"`
+	UserOrGroup   string   `json:"target"`
+	DiskLimit     capacity `json:"diskLimit"`
+	SoftDiskLimit capacity `json:"softDiskLimit"`
+	FileLimit     int      `json:"fileLimit"`
+	SoftFileLimit int      `json:"softFileLimit"`
+}
+
+func (c *Client) createQuotaRule(request quotaRuleRequest, clientID string, isSaas bool, connectorIP string) error {
+	log.Print("On createQuotaRule... ")
+	baseURL, _, err := c.getAPIRoot(request.WorkingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("createQuotaRule: Cannot get API root.")
+		return err
+	}
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	baseURL = fmt.Sprintf("%s/volumes/%s/%s/%s/quota-rules", baseURL, request.WorkingEnvironmentID, request.SvmName, request.VolumeName)
+	params := structs.Map(request)
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("POST", baseURL, params, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("createQuotaRule request failed ", statusCode)
+		return err
+	}
+	responseError := apiResponseChecker(statusCode, response, "createQuotaRule")
+	if responseError != nil {
+		return responseError
+	}
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, "quotaRule", "create", 10, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "quotaRule", "create", 10, 10, clientID, connectorIP)
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// getQuotaRule returns the quota rule matching type, qtree and target in the volume. An empty type is returned if it does not exist.
+func (c *Client) getQuotaRule(workingEnvironmentID string, svmName string, volumeName string, ruleType string, qtreeName string, userOrGroup string, clientID string, isSaas bool, connectorIP string) (quotaRuleResponse, error) {
+	log.Printf("getQuotaRule %s %s %s", ruleType, qtreeName, userOrGroup)
+	var result quotaRuleResponse
+	baseURL, _, err := c.getAPIRoot(workingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("getQuotaRule: Cannot get API root.")
+		return result, err
+	}
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	baseURL = fmt.Sprintf("%s/volumes/%s/%s/%s/quota-rules", baseURL, workingEnvironmentID, svmName, volumeName)
+	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("getQuotaRule request failed ", statusCode)
+		return result, err
+	}
+	responseError := apiResponseChecker(statusCode, response, "getQuotaRule")
+	if responseError != nil {
+		return result, responseError
+	}
+	var rules []quotaRuleResponse
+	if err := json.Unmarshal(response, &rules); err != nil {
+		log.Print("Failed to unmarshall response from getQuotaRule ", err)
+		return result, err
+	}
+	for _, rule := range rules {
+		if rule.Type == ruleType && rule.QtreeName == qtreeName && rule.UserOrGroup == userOrGroup {
+			return rule, nil
+		}
+	}
+	log.Print("Cannot find quota rule")
+	return result, nil
+}
+
+func (c *Client) updateQuotaRule(request quotaRuleRequest, clientID string, isSaas bool, connectorIP string) error {
+	log.Print("On updateQuotaRule... ")
+	baseURL, _, err := c.getAPIRoot(request.WorkingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("updateQuotaRule: Cannot get API root.")
+		return err
+	}
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	baseURL = fmt.Sprintf("%s/volumes/%s/%s/%s/quota-rules", baseURL, request.WorkingEnvironmentID, request.SvmName, request.VolumeName)
+	params := structs.Map(request)
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("PUT", baseURL, params, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("updateQuotaRule request failed ", statusCode)
+		return err
+	}
+	responseError := apiResponseChecker(statusCode, response, "updateQuotaRule")
+	if responseError != nil {
+		return responseError
+	}
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, "quotaRule", "update", 10, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "quotaRule", "update", 10, 10, clientID, connectorIP)
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *Client) deleteQuotaRule(workingEnvironmentID string, svmName string, volumeName string, ruleType string, qtreeName string, userOrGroup string, clientID string, isSaas bool, connectorIP string) error {
+	log.Print("On deleteQuotaRule... ")
+	baseURL, _, err := c.getAPIRoot(workingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("deleteQuotaRule: Cannot get API root.")
+		return err
+	}
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	baseURL = fmt.Sprintf("%s/volumes/%s/%s/%s/quota-rules?type=%s&qtreeName=%s&target=%s", baseURL, workingEnvironmentID, svmName, volumeName,
+		ruleType, url.QueryEscape(qtreeName), url.QueryEscape(userOrGroup))
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("DELETE", baseURL, nil, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("deleteQuotaRule request failed ", statusCode)
+		return err
+	}
+	responseError := apiResponseChecker(statusCode, response, "deleteQuotaRule")
+	if responseError != nil {
+		return responseError
+	}
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, "quotaRule", "delete", 10, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "quotaRule", "delete", 10, 10, clientID, connectorIP)
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
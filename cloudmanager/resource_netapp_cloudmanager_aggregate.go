@@ -49,12 +49,10 @@ func resourceAggregate() *schema.Resource {
 			"disk_size_size": {
 				Type:     schema.TypeInt,
 				Optional: true,
-				ForceNew: true,
 			},
 			"disk_size_unit": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				ForceNew:     true,
 				ValidateFunc: validation.StringInSlice([]string{"GB", "TB"}, true),
 			},
 			"home_node": {
@@ -66,7 +64,12 @@ func resourceAggregate() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 				Default:  "gp2",
-				ForceNew: true,
+			},
+			"migrate_on_disk_change": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Migrate the volumes to a new aggregate when provider_volume_type or the disk size changes, instead of replacing the aggregate",
 			},
 			"capacity_tier": {
 				Type:         schema.TypeString,
@@ -148,6 +151,30 @@ func resourceAggregate() *schema.Resource {
 				Computed:    true,
 				Description: "Unit of the available capacity",
 			},
+			"auto_grow_threshold_percent": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 99),
+				Description:  "Grow the aggregate when its available capacity drops below this percentage of the total capacity",
+			},
+			"auto_grow_number_of_disks": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Number of disks added to the aggregate when it is grown automatically",
+			},
+			"auto_grow_capacity_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Capacity added to an EBS Elastic Volumes aggregate when it is grown automatically",
+			},
+			"auto_grow_capacity_unit": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"Byte", "KB", "MB", "GB", "TB"}, true),
+				Description:  "Unit for the automatically added capacity",
+			},
 		},
 	}
 }
@@ -158,7 +185,6 @@ func resourceAggregateCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*Client)
 
 	clientID := d.Get("client_id").(string)
-	aggregate := createAggregateRequest{}
 
 	// Check deployment mode
 	isSaaS, connectorIP, err := client.checkDeploymentMode(d, clientID)
@@ -171,7 +197,6 @@ func resourceAggregateCreate(d *schema.ResourceData, meta interface{}) error {
 	if err != nil {
 		return fmt.Errorf("cannot find working environment")
 	}
-	aggregate.WorkingEnvironmentID = workingEnv.PublicID
 
 	// Validate that capacity increase fields are not used during creation
 	if capacitySize, ok := d.GetOk("increase_capacity_size"); ok && capacitySize.(int) > 0 {
@@ -181,80 +206,9 @@ func resourceAggregateCreate(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("increase_capacity_unit can only be used during aggregate updates, not during creation")
 	}
 
-	aggregate.Name = d.Get("name").(string)
-
-	if a, ok := d.GetOk("number_of_disks"); ok {
-		aggregate.NumberOfDisks, _ = a.(int)
-	}
-	if a, ok := d.GetOk("disk_size_size"); ok {
-		aggregate.DiskSize.Size, _ = a.(int)
-	}
-	if a, ok := d.GetOk("disk_size_unit"); ok {
-		aggregate.DiskSize.Unit = a.(string)
-	}
-
-	if a, ok := d.GetOk("home_node"); ok {
-		aggregate.HomeNode = a.(string)
-	}
-	if a, ok := d.GetOk("provider_volume_type"); ok {
-		aggregate.ProviderVolumeType = a.(string)
-		if aggregate.ProviderVolumeType == "io1" {
-			if a, ok := d.GetOk("iops"); ok {
-				aggregate.Iops = a.(int)
-			} else {
-				log.Printf("CreateAggregate: provider_volume_type is io1, but iops is not configured.")
-			}
-		}
-		if aggregate.ProviderVolumeType == "gp3" {
-			if a, ok := d.GetOk("iops"); ok {
-				aggregate.Iops = a.(int)
-			} else {
-				log.Printf("CreateAggregate: provider_volume_type is gp3, but iops is not configured.")
-			}
-			if a, ok := d.GetOk("throughput"); ok {
-				aggregate.Throughput = a.(int)
-			} else {
-				log.Printf("CreateAggregate: provider_volume_type is gp3, but throughput is not configured.")
-			}
-		}
-		if aggregate.ProviderVolumeType == "hyperdisk-balanced" {
-			if a, ok := d.GetOk("iops"); ok {
-				aggregate.Iops = a.(int)
-			} else {
-				log.Printf("CreateAggregate: provider_volume_type is hyperdisk-balanced, but iops is not configured.")
-			}
-			if a, ok := d.GetOk("throughput"); ok {
-				aggregate.Throughput = a.(int)
-			} else {
-				log.Printf("CreateAggregate: provider_volume_type is hyperdisk-balanced, but throughput is not configured.")
-			}
-		}
-	}
-	if a, ok := d.GetOk("capacity_tier"); ok {
-		if a.(string) != "NONE" {
-			aggregate.CapacityTier = a.(string)
-		}
-	} else if workingEnv.CloudProviderName == "Amazon" {
-		aggregate.CapacityTier = "S3"
-	} else if workingEnv.CloudProviderName == "Azure" {
-		aggregate.CapacityTier = "Blob"
-	} else if workingEnv.CloudProviderName == "GCP" {
-		aggregate.CapacityTier = "cloudStorage"
-	}
-
-	// Handle initial EV aggregate size for AWS EBS Elastic Volumes
-	if initialSize, ok := d.GetOk("initial_ev_aggregate_size"); ok {
-		if workingEnv.CloudProviderName != "Amazon" {
-			return fmt.Errorf("initial_ev_aggregate_size is only supported for Amazon Web Services (AWS) environments")
-		}
-
-		aggregate.InitialEvAggregateSize.Size = initialSize.(int)
-
-		if initialUnit, ok := d.GetOk("initial_ev_aggregate_unit"); ok {
-			aggregate.InitialEvAggregateSize.Unit = initialUnit.(string)
-		}
-
-		log.Printf("Setting initial EV aggregate size: %d %s", aggregate.InitialEvAggregateSize.Size, aggregate.InitialEvAggregateSize.Unit)
+	aggregate, err := buildCreateAggregateRequest(d, workingEnv)
+	if err != nil {
+		return err
 	}
 
 	res, err := client.createAggregate(&aggregate, clientID, isSaaS, connectorIP)
@@ -322,6 +276,17 @@ func resourceAggregateRead(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	if aggr.Name != d.Get("name").(string) && !importing {
+		// keep the state of an interrupted migration, so the next apply resumes it
+		pending, err := client.isAggregateMigrationPending(aggregate, d.Get("name").(string), workingEnv.WorkingEnvironmentType, clientID, isSaaS, connectorIP)
+		if err != nil {
+			return err
+		}
+		if pending {
+			log.Printf("[WARN] Migration of aggregate %s is not complete, its volumes are on aggregate %s_migrate, apply again to complete it", d.Get("name").(string), d.Get("name").(string))
+			return nil
+		}
+	}
 	if aggr.Name != d.Get("name").(string) {
 		return fmt.Errorf("expected aggregate name %v, Response could not find", aggr.Name)
 	}
@@ -435,6 +400,59 @@ func resourceAggregateUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	// Move the volumes to a new aggregate with the new disks, the new aggregate has all the configured settings
+	if d.HasChange("provider_volume_type") || d.HasChange("disk_size_size") || d.HasChange("disk_size_unit") {
+		// the old disk settings are kept in the state until the migration completes, so a failed migration is planned again
+		d.Partial(true)
+		err := migrateAggregate(client, d, workingEnvDetail, aggr, clientID, isSaaS, connectorIP)
+		if err != nil {
+			return err
+		}
+		d.Partial(false)
+		return resourceAggregateRead(d, meta)
+	}
+
+	// Grow the aggregate when its available capacity dropped below the auto grow threshold
+	threshold := d.Get("auto_grow_threshold_percent").(int)
+	belowThreshold := false
+	if threshold > 0 {
+		belowThreshold, err = aggregateBelowThreshold(aggr.TotalCapacity, aggr.AvailableCapacity, threshold)
+		if err != nil {
+			return err
+		}
+	}
+	if belowThreshold {
+		log.Printf("Available capacity %v %s of aggregate %s is below %d%%, growing the aggregate", aggr.AvailableCapacity.Size, aggr.AvailableCapacity.Unit, request.Name, threshold)
+		if disks := d.Get("auto_grow_number_of_disks").(int); disks > 0 {
+			growRequest := updateAggregateRequest{
+				WorkingEnvironmentID: workingEnvDetail.PublicID,
+				Name:                 request.Name,
+				NumberOfDisks:        disks,
+			}
+			err := client.updateAggregate(growRequest, clientID, isSaaS, connectorIP)
+			if err != nil {
+				return fmt.Errorf("failed to grow aggregate: %v", err)
+			}
+			currentNumber += disks
+		} else {
+			if workingEnvDetail.CloudProviderName != "Amazon" {
+				return fmt.Errorf("aggregate capacity increase is only supported for Amazon Web Services (AWS) environments, current environment is %s", workingEnvDetail.CloudProviderName)
+			}
+			increaseRequest := increaseAggregateCapacityRequest{
+				WorkingEnvironmentID: workingEnvDetail.PublicID,
+				AggregateName:        request.Name,
+				CapacityToAdd: diskSize{
+					Size: d.Get("auto_grow_capacity_size").(int),
+					Unit: d.Get("auto_grow_capacity_unit").(string),
+				},
+			}
+			err := client.increaseAggregateCapacity(increaseRequest, clientID, isSaaS, connectorIP)
+			if err != nil {
+				return fmt.Errorf("failed to grow aggregate: %v", err)
+			}
+		}
+	}
+
 	// Handle IOPS/Throughput update (hyperdisk-balanced only, enforced by CustomizeDiff)
 	if d.HasChange("iops") || d.HasChange("throughput") {
 		updateRequest := updateAggregateIopsThroughputRequest{
@@ -524,6 +542,14 @@ func resourceAggregateExists(d *schema.ResourceData, meta interface{}) (bool, er
 	} else {
 		// Normal operation, compare with ID
 		if res.Name != id {
+			// the old aggregate is deleted before the new one is renamed during a migration
+			pending, err := client.isAggregateMigrationPending(aggregate, id, workingEnv.WorkingEnvironmentType, clientID, isSaaS, connectorIP)
+			if err != nil {
+				return false, err
+			}
+			if pending {
+				return true, nil
+			}
 			d.SetId("")
 			return false, nil
 		}
@@ -578,6 +604,46 @@ func resourceAggregateCustomizeDiff(diff *schema.ResourceDiff, v interface{}) er
 		return fmt.Errorf("increase_capacity_size is required when increase_capacity_unit is specified")
 	}
 
+	// Validate the auto grow policy, either disks or capacity are added
+	if threshold := diff.Get("auto_grow_threshold_percent").(int); threshold > 0 {
+		growDisks := diff.Get("auto_grow_number_of_disks").(int) > 0
+		growCapacity := diff.Get("auto_grow_capacity_size").(int) > 0
+		if growDisks == growCapacity {
+			return fmt.Errorf("exactly one of auto_grow_number_of_disks or auto_grow_capacity_size is required when auto_grow_threshold_percent is specified")
+		}
+		if growCapacity && diff.Get("auto_grow_capacity_unit").(string) == "" {
+			return fmt.Errorf("auto_grow_capacity_unit is required when auto_grow_capacity_size is specified")
+		}
+		// the refreshed capacity is below the threshold, plan an update so the aggregate is grown on apply
+		if diff.Id() != "" {
+			total := capacity{Size: diff.Get("total_capacity_size").(float64), Unit: diff.Get("total_capacity_unit").(string)}
+			available := capacity{Size: diff.Get("available_capacity_size").(float64), Unit: diff.Get("available_capacity_unit").(string)}
+			belowThreshold, err := aggregateBelowThreshold(total, available, threshold)
+			if err != nil {
+				return err
+			}
+			if belowThreshold {
+				if err := diff.SetNewComputed("available_capacity_size"); err != nil {
+					return err
+				}
+				if err := diff.SetNewComputed("total_capacity_size"); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	// A disk change replaces the aggregate, unless the volumes are migrated to a new aggregate
+	if diff.Id() != "" && !diff.Get("migrate_on_disk_change").(bool) {
+		for _, key := range []string{"provider_volume_type", "disk_size_size", "disk_size_unit"} {
+			if diff.HasChange(key) {
+				if err := diff.ForceNew(key); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
 	// For non-hyperdisk-balanced volume types, force replacement on iops/throughput changes
 	// to preserve backward compatibility (gp3, io1, etc. previously had ForceNew: true)
 	providerVolumeType := diff.Get("provider_volume_type").(string)
@@ -619,3 +685,197 @@ func resourceAggregateImport(d *schema.ResourceData, meta interface{}) ([]*schem
 	return []*schema.ResourceData{d}, nil
 
 }
+
+// isAggregateMigrationPending returns true if the temporary aggregate of an interrupted migration exists
+func (c *Client) isAggregateMigrationPending(request aggregateRequest, name string, workingEnvironmentType string, clientID string, isSaaS bool, connectorIP string) (bool, error) {
+	tempName := name + "_migrate"
+	aggr, err := c.getAggregate(request, tempName, workingEnvironmentType, clientID, isSaaS, connectorIP)
+	if err != nil {
+		return false, err
+	}
+	return aggr.Name == tempName, nil
+}
+
+// aggregateBelowThreshold returns true if the available capacity is below the threshold percentage of the total capacity
+func aggregateBelowThreshold(total capacity, available capacity, threshold int) (bool, error) {
+	if total.Size <= 0 {
+		return false, nil
+	}
+	totalBytes, err := convertSizeUnit(total.Size, total.Unit, "B")
+	if err != nil {
+		return false, err
+	}
+	availableBytes, err := convertSizeUnit(available.Size, available.Unit, "B")
+	if err != nil {
+		return false, err
+	}
+	return availableBytes*100 < float64(threshold)*totalBytes, nil
+}
+
+// buildCreateAggregateRequest builds the request to create the aggregate from the configuration
+func buildCreateAggregateRequest(d *schema.ResourceData, workingEnv workingEnvironmentInfo) (createAggregateRequest, error) {
+	aggregate := createAggregateRequest{}
+	aggregate.WorkingEnvironmentID = workingEnv.PublicID
+	aggregate.Name = d.Get("name").(string)
+
+	if a, ok := d.GetOk("number_of_disks"); ok {
+		aggregate.NumberOfDisks, _ = a.(int)
+	}
+	if a, ok := d.GetOk("disk_size_size"); ok {
+		aggregate.DiskSize.Size, _ = a.(int)
+	}
+	if a, ok := d.GetOk("disk_size_unit"); ok {
+		aggregate.DiskSize.Unit = a.(string)
+	}
+
+	if a, ok := d.GetOk("home_node"); ok {
+		aggregate.HomeNode = a.(string)
+	}
+	if a, ok := d.GetOk("provider_volume_type"); ok {
+		aggregate.ProviderVolumeType = a.(string)
+		if aggregate.ProviderVolumeType == "io1" {
+			if a, ok := d.GetOk("iops"); ok {
+				aggregate.Iops = a.(int)
+			} else {
+				log.Printf("CreateAggregate: provider_volume_type is io1, but iops is not configured.")
+			}
+		}
+		if aggregate.ProviderVolumeType == "gp3" {
+			if a, ok := d.GetOk("iops"); ok {
+				aggregate.Iops = a.(int)
+			} else {
+				log.Printf("CreateAggregate: provider_volume_type is gp3, but iops is not configured.")
+			}
+			if a, ok := d.GetOk("throughput"); ok {
+				aggregate.Throughput = a.(int)
+			} else {
+				log.Printf("CreateAggregate: provider_volume_type is gp3, but throughput is not configured.")
+			}
+		}
+		if aggregate.ProviderVolumeType == "hyperdisk-balanced" {
+			if a, ok := d.GetOk("iops"); ok {
+				aggregate.Iops = a.(int)
+			} else {
+				log.Printf("CreateAggregate: provider_volume_type is hyperdisk-balanced, but iops is not configured.")
+			}
+			if a, ok := d.GetOk("throughput"); ok {
+				aggregate.Throughput = a.(int)
+			} else {
+				log.Printf("CreateAggregate: provider_volume_type is hyperdisk-balanced, but throughput is not configured.")
+			}
+		}
+	}
+	if a, ok := d.GetOk("capacity_tier"); ok {
+		if a.(string) != "NONE" {
+			aggregate.CapacityTier = a.(string)
+		}
+	} else if workingEnv.CloudProviderName == "Amazon" {
+		aggregate.CapacityTier = "S3"
+	} else if workingEnv.CloudProviderName == "Azure" {
+		aggregate.CapacityTier = "Blob"
+	} else if workingEnv.CloudProviderName == "GCP" {
+		aggregate.CapacityTier = "cloudStorage"
+	}
+
+	// Handle initial EV aggregate size for AWS EBS Elastic Volumes
+	if initialSize, ok := d.GetOk("initial_ev_aggregate_size"); ok {
+		if workingEnv.CloudProviderName != "Amazon" {
+			return aggregate, fmt.Errorf("initial_ev_aggregate_size is only supported for Amazon Web Services (AWS) environments")
+		}
+
+		aggregate.InitialEvAggregateSize.Size = initialSize.(int)
+
+		if initialUnit, ok := d.GetOk("initial_ev_aggregate_unit"); ok {
+			aggregate.InitialEvAggregateSize.Unit = initialUnit.(string)
+		}
+
+		log.Printf("Setting initial EV aggregate size: %d %s", aggregate.InitialEvAggregateSize.Size, aggregate.InitialEvAggregateSize.Unit)
+	}
+
+	return aggregate, nil
+}
+
+// migrateAggregate moves the volumes of the aggregate to a new aggregate built from the configuration.
+// The new aggregate is created under a temporary name, the old aggregate is deleted once it is empty and
+// the new aggregate is renamed to the name of the old one. The state keeps the old disk settings when a step fails,
+// and Exists and Read accept the temporary aggregate, so applying again resumes the migration.
+func migrateAggregate(client *Client, d *schema.ResourceData, workingEnv workingEnvironmentInfo, aggr aggregateResult, clientID string, isSaaS bool, connectorIP string) error {
+	name := d.Get("name").(string)
+	if aggr.IsRoot {
+		return fmt.Errorf("aggregate %s is a root aggregate and can not be migrated", name)
+	}
+	tempName := name + "_migrate"
+
+	request, err := buildCreateAggregateRequest(d, workingEnv)
+	if err != nil {
+		return err
+	}
+	request.Name = tempName
+	if request.NumberOfDisks == 0 {
+		request.NumberOfDisks = len(aggr.Disks)
+	}
+	if request.HomeNode == "" {
+		request.HomeNode = aggr.HomeNode
+	}
+
+	volumes, err := client.getVolume(volumeRequest{WorkingEnvironmentID: workingEnv.PublicID}, clientID, isSaaS, connectorIP)
+	if err != nil {
+		return err
+	}
+	var toMove []volumeResponse
+	for _, vol := range volumes {
+		for _, constituent := range vol.Constituents {
+			if constituent.AggregateName == name {
+				return fmt.Errorf("aggregate %s holds constituents of flexgroup volume %s and can not be migrated", name, vol.Name)
+			}
+		}
+		if vol.AggregateName == name {
+			toMove = append(toMove, vol)
+		}
+	}
+
+	existing, err := client.getAggregate(aggregateRequest{WorkingEnvironmentID: workingEnv.PublicID}, tempName, workingEnv.WorkingEnvironmentType, clientID, isSaaS, connectorIP)
+	if err != nil {
+		return err
+	}
+	if existing.Name == tempName {
+		log.Printf("[INFO] Migrating aggregate %s (step 1/4): reusing aggregate %s", name, tempName)
+	} else {
+		log.Printf("[INFO] Migrating aggregate %s (step 1/4): creating aggregate %s with %d %s disks", name, tempName, request.NumberOfDisks, request.ProviderVolumeType)
+		if _, err := client.createAggregate(&request, clientID, isSaaS, connectorIP); err != nil {
+			return fmt.Errorf("failed to create aggregate %s: %v", tempName, err)
+		}
+	}
+
+	for i, vol := range toMove {
+		log.Printf("[INFO] Migrating aggregate %s (step 2/4): moving volume %s (%d/%d)", name, vol.Name, i+1, len(toMove))
+		moveRequest := volumeMoveRequest{}
+		moveRequest.TargetAggregate.Name = tempName
+		if err := client.moveVolume(workingEnv.PublicID, vol.SvmName, vol.Name, moveRequest, clientID, isSaaS, connectorIP); err != nil {
+			return fmt.Errorf("failed to move volume %s to aggregate %s: %v", vol.Name, tempName, err)
+		}
+	}
+
+	// the old aggregate is already gone when a migration is resumed after the delete step
+	if aggr.Name == name {
+		log.Printf("[INFO] Migrating aggregate %s (step 3/4): deleting the old aggregate", name)
+		deleted, err := client.deleteAggregateIfEmpty(workingEnv.PublicID, workingEnv.WorkingEnvironmentType, name, clientID, isSaaS, connectorIP)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return fmt.Errorf("aggregate %s still holds volumes after the migration", name)
+		}
+	}
+
+	log.Printf("[INFO] Migrating aggregate %s (step 4/4): renaming aggregate %s to %s", name, tempName, name)
+	renameRequest := renameAggregateRequest{}
+	renameRequest.WorkingEnvironmentID = workingEnv.PublicID
+	renameRequest.Name = tempName
+	renameRequest.NewName = name
+	if err := client.renameAggregate(renameRequest, clientID, isSaaS, connectorIP); err != nil {
+		return fmt.Errorf("failed to rename aggregate %s to %s: %v", tempName, name, err)
+	}
+	log.Printf("[INFO] Migrated aggregate %s, %d volumes moved", name, len(toMove))
+	return nil
+}
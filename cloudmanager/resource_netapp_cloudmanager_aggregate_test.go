@@ -352,3 +352,28 @@ func testAccAggregateConfigIncreaseUnitValidationFail() string {
 	}
   `
 }
+
+func TestAggregateBelowThreshold(t *testing.T) {
+	cases := []struct {
+		name      string
+		total     capacity
+		available capacity
+		expected  bool
+	}{
+		{"mixed units above threshold", capacity{Size: 1, Unit: "TB"}, capacity{Size: 200, Unit: "GB"}, false},
+		{"mixed units below threshold", capacity{Size: 1, Unit: "TB"}, capacity{Size: 100, Unit: "GB"}, true},
+		{"byte units", capacity{Size: 1000, Unit: "Byte"}, capacity{Size: 50, Unit: "Byte"}, true},
+		{"unknown total", capacity{}, capacity{Size: 1, Unit: "GB"}, false},
+	}
+	for _, c := range cases {
+		below, err := aggregateBelowThreshold(c.total, c.available, 15)
+		if err != nil {
+			t.Errorf("%s: unexpected error %s", c.name, err)
+		} else if below != c.expected {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, below)
+		}
+	}
+	if _, err := aggregateBelowThreshold(capacity{Size: 1, Unit: "PB"}, capacity{Size: 1, Unit: "GB"}, 15); err == nil {
+		t.Error("expected an error for an unknown unit")
+	}
+}
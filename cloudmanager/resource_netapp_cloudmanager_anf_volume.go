@@ -167,7 +167,11 @@ func resourceCVSAzureVolumeCreate(d *schema.ResourceData, meta interface{}) erro
 	volume.Name = d.Get("name").(string)
 	volume.Location = d.Get("location").(string)
 	volume.ServiceLevel = d.Get("service_level").(string)
-	volume.Size = math.Round(convertSizeUnit(d.Get("size").(float64), d.Get("size_unit").(string), "B")*10) / 10
+	size, err := convertSizeUnit(d.Get("size").(float64), d.Get("size_unit").(string), "B")
+	if err != nil {
+		return err
+	}
+	volume.Size = math.Round(size*10) / 10
 	volume.SubnetName = d.Get("subnet").(string)
 	volume.VolumePath = d.Get("volume_path").(string)
 	volume.VirtualNetworkName = d.Get("virtual_network").(string)
@@ -205,7 +209,7 @@ func resourceCVSAzureVolumeCreate(d *schema.ResourceData, meta interface{}) erro
 	info.ResourceGroupsName = d.Get("resource_groups").(string)
 	info.NetAppAccountName = d.Get("netapp_account").(string)
 	info.CapacityPools = d.Get("capacity_pool").(string)
-	err := client.createANFVolume(volume, info, clientID)
+	err = client.createANFVolume(volume, info, clientID)
 	if err != nil {
 		return err
 	}
@@ -235,7 +239,11 @@ func resourceCVSAzureVolumeRead(d *schema.ResourceData, meta interface{}) error
 		log.Print("Error reading volume")
 		return err
 	}
-	d.Set("size", math.Round(convertSizeUnit(result.Size, "B", d.Get("size_unit").(string))*10)/10)
+	size, err := convertSizeUnit(result.Size, "B", d.Get("size_unit").(string))
+	if err != nil {
+		return err
+	}
+	d.Set("size", math.Round(size*10)/10)
 	d.Set("volume_path", result.VolumePath)
 	d.Set("protocol_types", result.ProtocolTypes)
 	d.Set("service_level", result.ServiceLevel)
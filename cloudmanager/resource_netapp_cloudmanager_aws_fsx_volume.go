@@ -217,9 +217,13 @@ func resourceFSXVolumeRead(d *schema.ResourceData, meta interface{}) error {
 			if _, ok := d.GetOk("export_policy_type"); ok {
 				d.Set("export_policy_type", volume.ExportPolicyInfo.PolicyType)
 			}
-			if d.Get("unit") != "GB" {
-				d.Set("size", convertSizeUnit(volume.Size.Size, volume.Size.Unit, d.Get("unit").(string)))
-				d.Set("unit", d.Get("unit").(string))
+			if unit := d.Get("unit").(string); unit != "GB" && unit != "" {
+				size, err := convertSizeUnit(volume.Size.Size, volume.Size.Unit, unit)
+				if err != nil {
+					return fmt.Errorf("error reading volume size: %s", err)
+				}
+				d.Set("size", size)
+				d.Set("unit", unit)
 			} else {
 				d.Set("size", volume.Size.Size)
 				d.Set("unit", volume.Size.Unit)
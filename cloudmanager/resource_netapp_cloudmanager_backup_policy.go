@@ -0,0 +1,249 @@
+package cloudmanager
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceBackupPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBackupPolicyCreate,
+		Read:   resourceBackupPolicyRead,
+		Delete: resourceBackupPolicyDelete,
+		Exists: resourceBackupPolicyExists,
+		Update: resourceBackupPolicyUpdate,
+		Importer: &schema.ResourceImporter{
+			State: resourceBackupPolicyImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"rule": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"label": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"Hourly", "Daily", "Weekly", "Monthly", "Yearly"}, false),
+						},
+						"retention": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"archive_after_days": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"object_lock": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"GOVERNANCE", "COMPLIANCE", "UNLOCKED", "LOCKED"}, false),
+			},
+		},
+	}
+}
+
+func resourceBackupPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Creating backup policy: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, true, "")
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+	cbs := cbsRequest{}
+	cbs.WorkingEnvironmentID = workingEnv.PublicID
+	cbs.AccountID = d.Get("account_id").(string)
+
+	policy := buildBackupPolicyRequest(d)
+	err = client.createCBSBackupPolicy(cbs, policy, clientID)
+	if err != nil {
+		log.Print("Error creating backup policy")
+		return err
+	}
+	d.SetId(policy.Name)
+
+	return resourceBackupPolicyRead(d, meta)
+}
+
+func resourceBackupPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Reading backup policy: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, true, "")
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+	cbs := cbsRequest{}
+	cbs.WorkingEnvironmentID = workingEnv.PublicID
+	cbs.AccountID = d.Get("account_id").(string)
+	name := d.Get("name").(string)
+
+	policy, err := client.getCBSBackupPolicy(cbs, name, clientID)
+	if err != nil {
+		log.Print("Error reading backup policy")
+		return err
+	}
+	if policy.Name != name {
+		return fmt.Errorf("expected backup policy name %v, Response could not find", name)
+	}
+
+	if strings.Contains(d.Id(), ",") {
+		d.SetId(policy.Name)
+		d.Set("working_environment_name", workingEnv.Name)
+	}
+	d.Set("archive_after_days", policy.ArchiveAfteDays)
+	if policy.ObjectLock != "NONE" {
+		d.Set("object_lock", policy.ObjectLock)
+	}
+	rules := make([]interface{}, 0, len(policy.Rules))
+	for _, rule := range policy.Rules {
+		rules = append(rules, map[string]interface{}{
+			"label":     rule.Label,
+			"retention": rule.Rentention,
+		})
+	}
+	if err := d.Set("rule", rules); err != nil {
+		return fmt.Errorf("error reading backup policy rule: %s", err)
+	}
+
+	return nil
+}
+
+func resourceBackupPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Updating backup policy: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, true, "")
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+	cbs := cbsRequest{}
+	cbs.WorkingEnvironmentID = workingEnv.PublicID
+	cbs.AccountID = d.Get("account_id").(string)
+
+	if d.HasChange("rule") || d.HasChange("archive_after_days") {
+		err = client.updateCBSBackupPolicy(cbs, buildBackupPolicyRequest(d), clientID)
+		if err != nil {
+			log.Print("Error updating backup policy")
+			return err
+		}
+	}
+
+	return resourceBackupPolicyRead(d, meta)
+}
+
+func resourceBackupPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Deleting backup policy: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, true, "")
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+	cbs := cbsRequest{}
+	cbs.WorkingEnvironmentID = workingEnv.PublicID
+	cbs.AccountID = d.Get("account_id").(string)
+
+	err = client.deleteCBSBackupPolicy(cbs, d.Get("name").(string), clientID)
+	if err != nil {
+		log.Print("Error deleting backup policy")
+		return err
+	}
+	return nil
+}
+
+func resourceBackupPolicyExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	log.Printf("Checking existence of backup policy: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, true, "")
+	if err != nil {
+		return false, fmt.Errorf("cannot find working environment")
+	}
+	cbs := cbsRequest{}
+	cbs.WorkingEnvironmentID = workingEnv.PublicID
+	cbs.AccountID = d.Get("account_id").(string)
+
+	name := d.Get("name").(string)
+	policy, err := client.getCBSBackupPolicy(cbs, name, clientID)
+	if err != nil {
+		log.Print("Error getting backup policy")
+		return false, err
+	}
+	if policy.Name != name {
+		d.SetId("")
+		return false, nil
+	}
+	return true, nil
+}
+
+func resourceBackupPolicyImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ",")
+	if len(parts) != 4 {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'client_id,account_id,working_environment_name,name'", d.Id())
+	}
+
+	d.Set("client_id", parts[0])
+	d.Set("account_id", parts[1])
+	d.Set("working_environment_name", parts[2])
+	d.Set("name", parts[3])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func buildBackupPolicyRequest(d *schema.ResourceData) backupPolicy {
+	policy := backupPolicy{
+		Name:            d.Get("name").(string),
+		Rule:            []ruleDetails{},
+		ArchiveAfteDays: d.Get("archive_after_days").(string),
+		ObjectLock:      d.Get("object_lock").(string),
+	}
+	for _, v := range d.Get("rule").([]interface{}) {
+		rule := v.(map[string]interface{})
+		policy.Rule = append(policy.Rule, ruleDetails{
+			Label:     rule["label"].(string),
+			Retention: rule["retention"].(string),
+		})
+	}
+	return policy
+}
@@ -0,0 +1,188 @@
+package cloudmanager
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceBackupRestore() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBackupRestoreCreate,
+		Read:   resourceBackupRestoreRead,
+		Delete: resourceBackupRestoreDelete,
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"source_working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"source_working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"source_volume_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"snapshot": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"destination_working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"destination_working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"destination_svm_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"destination_volume_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"destination_aggregate_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"files": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"destination_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceBackupRestoreCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Restoring cloud backup: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	sourceWEInfo, destWEInfo, err := client.getWorkingEnvironmentDetailForSnapMirror(d, clientID, true, "")
+	if err != nil {
+		log.Print("Cannot find working environment")
+		return err
+	}
+
+	restore := cbsRestoreRequest{}
+	restore.Source.WorkingEnvironmentID = sourceWEInfo.PublicID
+	restore.Source.VolumeID = d.Get("source_volume_id").(string)
+	restore.Source.Snapshot = d.Get("snapshot").(string)
+	restore.Target.WorkingEnvironmentID = destWEInfo.PublicID
+	restore.Target.VolumeName = d.Get("destination_volume_name").(string)
+	restore.Target.Svm = destWEInfo.SvmName
+	if v, ok := d.GetOk("destination_svm_name"); ok {
+		restore.Target.Svm = v.(string)
+	}
+	for _, v := range d.Get("files").([]interface{}) {
+		restore.File = append(restore.File, cbsRestoreFile{Path: v.(string)})
+	}
+	if len(restore.File) != 0 {
+		if _, ok := d.GetOk("destination_aggregate_name"); ok {
+			return fmt.Errorf("destination_aggregate_name is only supported when restoring a volume, files are restored to the existing volume %s", restore.Target.VolumeName)
+		}
+		restore.Target.Path = d.Get("destination_path").(string)
+	} else {
+		if _, ok := d.GetOk("destination_path"); ok {
+			return fmt.Errorf("destination_path is only supported when restoring files")
+		}
+		restore.Target.Aggregate = d.Get("destination_aggregate_name").(string)
+	}
+
+	cbs := cbsRequest{}
+	cbs.AccountID = d.Get("account_id").(string)
+	cbs.WorkingEnvironmentID = destWEInfo.PublicID
+	res, err := client.restoreCBS(cbs, restore, clientID)
+	if err != nil {
+		log.Print("Error restoring cloud backup of volume ", restore.Source.VolumeID)
+		return err
+	}
+	// the job is tracked from the state before waiting, so a restore that outlasts the wait is not started again
+	d.SetId(res.ID)
+	d.Set("destination_svm_name", restore.Target.Svm)
+
+	// restoring a large volume can take hours
+	jobs, err := client.waitOnJobCompletionCBS(res.ID, cbs, "restore", getCBSRestoreType(restore), 360, 30, clientID)
+	if err != nil {
+		if len(jobs) != 0 && jobs[0].JobStatus == "FAILED" {
+			return err
+		}
+		log.Printf("[WARN] cloud backup restore job %s is still running, its status is refreshed with the state: %s", res.ID, err)
+	}
+
+	return resourceBackupRestoreRead(d, meta)
+}
+
+func resourceBackupRestoreRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Reading cloud backup restore: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	accessTokenResult, err := client.getAccessToken()
+	if err != nil {
+		log.Print("in resourceBackupRestoreRead, failed to get AccessToken")
+		return err
+	}
+	client.Token = accessTokenResult.Token
+
+	// the restore is not repeated when its job is no longer listed, the last known status is kept
+	jobs, err := client.checkJobStatusCBS(d.Id(), d.Get("account_id").(string), d.Get("destination_working_environment_id").(string), clientID)
+	if err != nil {
+		log.Print("Error reading cloud backup restore job ", d.Id())
+		return err
+	}
+	if len(jobs) == 0 {
+		log.Printf("cloud backup restore job %s is no longer listed", d.Id())
+		return nil
+	}
+	d.Set("status", jobs[0].JobStatus)
+
+	return nil
+}
+
+// resourceBackupRestoreDelete only removes the restore from the state, the restored volume and files are kept
+func resourceBackupRestoreDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Removing cloud backup restore %s from the state, the restored data is kept", d.Id())
+	return nil
+}
@@ -3,7 +3,9 @@ package cloudmanager
 import (
 	"fmt"
 	"log"
+	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
@@ -14,12 +16,12 @@ func resourceCBS() *schema.Resource {
 		Create: resourceCBSCreate,
 		Read:   resourceCBSRead,
 		Delete: resourceCBSDelete,
-		// Update: resourceCBSUpdate,
+		Update: resourceCBSUpdate,
 		// Exists: resourceCBSExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceCBSImport,
 		},
-		// CustomizeDiff: resourceCBSCustomizeDiff,
+		CustomizeDiff: resourceCBSCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"account_id": {
 				Type:     schema.TypeString,
@@ -39,19 +41,20 @@ func resourceCBS() *schema.Resource {
 			"cloud_provider": {
 				Type:         schema.TypeString,
 				Optional:     true,
+				Computed:     true,
 				ForceNew:     true,
 				ValidateFunc: validation.StringInSlice([]string{"AWS", "AZURE", "GCP"}, false),
 			},
 			"region": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"aws_cbs_parameters": {
 				Type:     schema.TypeSet,
 				MaxItems: 1,
 				Optional: true,
-				ForceNew: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"aws_account_id": {
@@ -149,18 +152,20 @@ func resourceCBS() *schema.Resource {
 			"bucket": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"ip_space": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"backup_policy": {
 				Type:     schema.TypeSet,
 				MaxItems: 1,
 				Optional: true,
-				ForceNew: true,
+				Computed: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"name": {
@@ -180,12 +185,10 @@ func resourceCBS() *schema.Resource {
 												"label": {
 													Type:     schema.TypeString,
 													Optional: true,
-													ForceNew: true,
 												},
 												"retention": {
 													Type:     schema.TypeString,
 													Optional: true,
-													ForceNew: true,
 												},
 											},
 										},
@@ -207,12 +210,10 @@ func resourceCBS() *schema.Resource {
 			"auto_backup_enabled": {
 				Type:     schema.TypeBool,
 				Optional: true,
-				ForceNew: true,
 			},
 			"max_transfer_rate": {
 				Type:     schema.TypeInt,
 				Optional: true,
-				ForceNew: true,
 			},
 			"export_existing_snapshots": {
 				Type:     schema.TypeBool,
@@ -222,24 +223,20 @@ func resourceCBS() *schema.Resource {
 			"volumes": {
 				Type:     schema.TypeList,
 				Optional: true,
-				ForceNew: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"volume_name": {
 							Type:     schema.TypeString,
 							Required: true,
-							ForceNew: true,
 						},
 						"mode": {
 							Type:     schema.TypeString,
 							Optional: true,
-							ForceNew: true,
 						},
 						"backup_policy": {
 							Type:     schema.TypeSet,
 							MaxItems: 1,
 							Optional: true,
-							ForceNew: true,
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"name": {
@@ -259,12 +256,10 @@ func resourceCBS() *schema.Resource {
 															"label": {
 																Type:     schema.TypeString,
 																Optional: true,
-																ForceNew: true,
 															},
 															"retention": {
 																Type:     schema.TypeString,
 																Optional: true,
-																ForceNew: true,
 															},
 														},
 													},
@@ -302,7 +297,7 @@ func resourceCBSCreate(d *schema.ResourceData, meta interface{}) error {
 	clientID := d.Get("client_id").(string)
 	createCBSRequest := cbsRequest{}
 	createCBSVolumeRequest := &cbsVolumeRequest{}
-	var volumesIDNameMap = map[string]map[string]string{}
+	var volumesIDNameMap map[string]map[string]string
 
 	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, true, "")
 	if err != nil {
@@ -355,42 +350,10 @@ func resourceCBSCreate(d *schema.ResourceData, meta interface{}) error {
 	// }
 
 	if volumes, ok := d.GetOk("volumes"); ok {
-		volumesSet := volumes.([]interface{})
-		volumesConfigs := make([]cbsVolume, 0, len(volumesSet))
-		for _, x := range volumesSet {
-			volume := cbsVolume{}
-			volumeConfig := x.(map[string]interface{})
-			volumeName := volumeConfig["volume_name"].(string)
-			volumeRequest := volumeRequest{}
-			volumeRequest.Name = volumeName
-			volumeRequest.WorkingEnvironmentID = createCBSRequest.WorkingEnvironmentID
-			getVolmeDetails, err := client.getVolume(volumeRequest, clientID, true, "")
-			if err != nil {
-				log.Print("Error getting volumes details ", createCBSVolumeRequest.Volume)
-				return err
-			}
-			for _, vol := range getVolmeDetails {
-				if vol.Name == volumeName {
-					volume.VolumeID = vol.ID
-					volumesIDNameMap[vol.ID] = make(map[string]string)
-					volumesIDNameMap[vol.ID]["name"] = volumeName
-					break
-				}
-			}
-			if volume.VolumeID == "" {
-				return fmt.Errorf("error retrieving volumes details: volume %s does not exist", volumeName)
-			}
-			if m, ok := volumeConfig["mode"]; ok {
-				volume.Mode = m.(string)
-			}
-			if b, ok := volumeConfig["backup_policy"]; ok {
-				backupPolicy := b.(*schema.Set)
-				volume.BackupPolicy = expandBackupPolicy(backupPolicy)
-			}
-			volumesConfigs = append(volumesConfigs, volume)
+		createCBSVolumeRequest.Volume, volumesIDNameMap, err = expandCBSVolumes(client, volumes.([]interface{}), createCBSRequest.WorkingEnvironmentID, clientID)
+		if err != nil {
+			return err
 		}
-		createCBSVolumeRequest.Volume = volumesConfigs
-
 	}
 
 	res, err := client.createCBS(createCBSRequest, clientID)
@@ -430,10 +393,169 @@ func resourceCBSRead(d *schema.ResourceData, meta interface{}) error {
 		log.Print("Error retrieving WE backup details")
 		return err
 	}
-	if res.ID == workingEnv.PublicID {
-		return nil
+	if res.ID != workingEnv.PublicID {
+		return fmt.Errorf("error retrieving cloud backup: cloud backup does not exist")
+	}
+	isImport := strings.Contains(d.Id(), ",")
+	if isImport {
+		d.SetId(workingEnv.PublicID)
+		d.Set("working_environment_name", workingEnv.Name)
+	}
+
+	cloudProvider := strings.ToUpper(res.CloudProvider)
+	d.Set("cloud_provider", cloudProvider)
+	d.Set("region", res.Region)
+	d.Set("bucket", res.Bucket)
+	d.Set("ip_space", res.IPSpace)
+	d.Set("auto_backup_enabled", res.AutoBackupEnabled)
+	d.Set("max_transfer_rate", res.MaxTransferRate)
+	if res.BackupPolicy.Name != "" {
+		if err := d.Set("backup_policy", flattenBackupPolicy(res.BackupPolicy, res.ObjectLock)); err != nil {
+			return fmt.Errorf("error reading cloud backup backup_policy: %s", err)
+		}
+	}
+
+	// the credentials are not returned, only the values returned are refreshed
+	if cloudProvider == "AWS" {
+		aws := getCBSParameters(d, "aws_cbs_parameters")
+		if isImport {
+			aws["aws_account_id"] = res.ProviderAccountID
+		}
+		aws["archive_storage_class"] = res.ArchiveStorageClass
+		if err := d.Set("aws_cbs_parameters", []interface{}{aws}); err != nil {
+			return fmt.Errorf("error reading cloud backup aws_cbs_parameters: %s", err)
+		}
+	} else if cloudProvider == "AZURE" {
+		azure := getCBSParameters(d, "azure_cbs_parameters")
+		if isImport {
+			azure["subscription"] = res.ProviderAccountID
+		}
+		azure["resource_group"] = res.ResourceGroup
+		azure["storage_account"] = res.StorageAccount
+		if err := d.Set("azure_cbs_parameters", []interface{}{azure}); err != nil {
+			return fmt.Errorf("error reading cloud backup azure_cbs_parameters: %s", err)
+		}
+	}
+
+	backupVolumes, err := client.getCBSVolume(readCBSRequest, clientID)
+	if err != nil {
+		log.Print("Error retrieving volume backup details")
+		return err
+	}
+	if err := d.Set("volumes", flattenCBSVolumes(d.Get("volumes").([]interface{}), backupVolumes, res, isImport)); err != nil {
+		return fmt.Errorf("error reading cloud backup volumes: %s", err)
 	}
-	return fmt.Errorf("error retrieving cloud backup: cloud backup does not exist")
+	return nil
+}
+
+func resourceCBSUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Updating cloud backup: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+	updateCBSRequest := cbsRequest{}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, true, "")
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+	updateCBSRequest.WorkingEnvironmentID = workingEnv.PublicID
+	updateCBSRequest.AccountID = d.Get("account_id").(string)
+
+	if d.HasChange("backup_policy") || d.HasChange("auto_backup_enabled") || d.HasChange("max_transfer_rate") || d.HasChange("aws_cbs_parameters") {
+		update := cbsUpdateRequest{}
+		update.AutoBackupEnabled = d.Get("auto_backup_enabled").(bool)
+		update.MaxTransferRate = d.Get("max_transfer_rate").(int)
+		if v, ok := d.GetOk("backup_policy"); ok {
+			update.BackupPolicy = expandBackupPolicy(v.(*schema.Set))
+		}
+		if v, ok := d.GetOk("aws_cbs_parameters"); ok {
+			update.ArchiveStorageClass = expandAws(v.(*schema.Set)).ArchiveStorageClass
+		}
+		err = client.updateCBS(updateCBSRequest, update, clientID)
+		if err != nil {
+			log.Print("Error updating cloud backup on the working environment ", updateCBSRequest.WorkingEnvironmentID)
+			return err
+		}
+	}
+
+	if d.HasChange("volumes") {
+		oldVolumes, newVolumes := d.GetChange("volumes")
+		oldVolumesByName := make(map[string]map[string]interface{})
+		for _, x := range oldVolumes.([]interface{}) {
+			volumeConfig := x.(map[string]interface{})
+			oldVolumesByName[volumeConfig["volume_name"].(string)] = volumeConfig
+		}
+		newVolumesByName := make(map[string]bool)
+		addedVolumes := make([]interface{}, 0)
+		modifiedVolumes := make([]interface{}, 0)
+		for _, x := range newVolumes.([]interface{}) {
+			volumeConfig := x.(map[string]interface{})
+			volumeName := volumeConfig["volume_name"].(string)
+			newVolumesByName[volumeName] = true
+			oldConfig, ok := oldVolumesByName[volumeName]
+			if !ok {
+				addedVolumes = append(addedVolumes, x)
+			} else if oldConfig["mode"].(string) != volumeConfig["mode"].(string) ||
+				!reflect.DeepEqual(expandBackupPolicy(oldConfig["backup_policy"].(*schema.Set)), expandBackupPolicy(volumeConfig["backup_policy"].(*schema.Set))) {
+				modifiedVolumes = append(modifiedVolumes, x)
+			}
+		}
+
+		// removed volumes are deactivated only, their existing backups are kept
+		removedVolumes := make(map[string]bool)
+		for volumeName := range oldVolumesByName {
+			if !newVolumesByName[volumeName] {
+				removedVolumes[volumeName] = true
+			}
+		}
+		if len(removedVolumes) != 0 {
+			backupVolumes, err := client.getCBSVolume(updateCBSRequest, clientID)
+			if err != nil {
+				log.Print("Error retrieving volume backup details")
+				return err
+			}
+			for _, eachVolume := range backupVolumes {
+				if removedVolumes[eachVolume.Name] {
+					err = client.deactivateCBSVolume(updateCBSRequest, eachVolume.ID, clientID)
+					if err != nil {
+						log.Print("Error deactivating cloud backup on the volume ", eachVolume.Name)
+						return err
+					}
+				}
+			}
+		}
+
+		if len(modifiedVolumes) != 0 {
+			volumes, _, err := expandCBSVolumes(client, modifiedVolumes, updateCBSRequest.WorkingEnvironmentID, clientID)
+			if err != nil {
+				return err
+			}
+			for _, volume := range volumes {
+				err = client.updateCBSVolume(updateCBSRequest, volume, clientID)
+				if err != nil {
+					log.Print("Error updating cloud backup on the volume ", volume.VolumeID)
+					return err
+				}
+			}
+		}
+
+		if len(addedVolumes) != 0 {
+			addCBSVolumeRequest := cbsVolumeRequest{}
+			var volumesIDNameMap map[string]map[string]string
+			addCBSVolumeRequest.Volume, volumesIDNameMap, err = expandCBSVolumes(client, addedVolumes, updateCBSRequest.WorkingEnvironmentID, clientID)
+			if err != nil {
+				return err
+			}
+			_, err = client.enableBackupForSingleORMultipleVolumes(updateCBSRequest, addCBSVolumeRequest, clientID, volumesIDNameMap)
+			if err != nil {
+				log.Print("Error enabling cloud backup on the volumes ", addCBSVolumeRequest.Volume)
+				return err
+			}
+		}
+	}
+
+	return resourceCBSRead(d, meta)
 }
 
 func resourceCBSDelete(d *schema.ResourceData, meta interface{}) error {
@@ -482,6 +604,160 @@ func resourceCBSDelete(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+// resourceCBSCustomizeDiff replaces the cloud backup when the AWS parameters change, except for the archive storage class
+func resourceCBSCustomizeDiff(diff *schema.ResourceDiff, v interface{}) error {
+	if diff.Id() != "" && diff.HasChange("aws_cbs_parameters") {
+		oldAws, newAws := diff.GetChange("aws_cbs_parameters")
+		oldParams := expandAws(oldAws.(*schema.Set))
+		newParams := expandAws(newAws.(*schema.Set))
+		oldParams.ArchiveStorageClass = ""
+		newParams.ArchiveStorageClass = ""
+		if !reflect.DeepEqual(oldParams, newParams) {
+			if err := diff.ForceNew("aws_cbs_parameters"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// expandCBSVolumes returns the backup settings of the volumes and a map of the volume IDs to their names
+func expandCBSVolumes(client *Client, volumesList []interface{}, workingEnvironmentID string, clientID string) ([]cbsVolume, map[string]map[string]string, error) {
+	volumesConfigs := make([]cbsVolume, 0, len(volumesList))
+	volumesIDNameMap := map[string]map[string]string{}
+	for _, x := range volumesList {
+		volume := cbsVolume{}
+		volumeConfig := x.(map[string]interface{})
+		volumeName := volumeConfig["volume_name"].(string)
+		volumeRequest := volumeRequest{}
+		volumeRequest.Name = volumeName
+		volumeRequest.WorkingEnvironmentID = workingEnvironmentID
+		getVolmeDetails, err := client.getVolume(volumeRequest, clientID, true, "")
+		if err != nil {
+			log.Print("Error getting volumes details ", volumeName)
+			return nil, nil, err
+		}
+		for _, vol := range getVolmeDetails {
+			if vol.Name == volumeName {
+				volume.VolumeID = vol.ID
+				volumesIDNameMap[vol.ID] = make(map[string]string)
+				volumesIDNameMap[vol.ID]["name"] = volumeName
+				break
+			}
+		}
+		if volume.VolumeID == "" {
+			return nil, nil, fmt.Errorf("error retrieving volumes details: volume %s does not exist", volumeName)
+		}
+		if m, ok := volumeConfig["mode"]; ok {
+			volume.Mode = m.(string)
+		}
+		if b, ok := volumeConfig["backup_policy"]; ok {
+			backupPolicy := b.(*schema.Set)
+			volume.BackupPolicy = expandBackupPolicy(backupPolicy)
+		}
+		volumesConfigs = append(volumesConfigs, volume)
+	}
+	return volumesConfigs, volumesIDNameMap, nil
+}
+
+func resourceCBSImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ",")
+	if len(parts) != 3 {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'client_id,account_id,working_environment_name'", d.Id())
+	}
+
+	d.Set("client_id", parts[0])
+	d.Set("account_id", parts[1])
+	d.Set("working_environment_name", parts[2])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// getCBSParameters returns a copy of the cloud provider parameters in the state, or an empty map if not set
+func getCBSParameters(d *schema.ResourceData, key string) map[string]interface{} {
+	params := make(map[string]interface{})
+	if v, ok := d.GetOk(key); ok {
+		for _, x := range v.(*schema.Set).List() {
+			for k, value := range x.(map[string]interface{}) {
+				params[k] = value
+			}
+		}
+	}
+	return params
+}
+
+func flattenBackupPolicy(policy backupPolicyResult, objectLock string) []interface{} {
+	rules := make([]interface{}, 0, len(policy.Rules))
+	for _, rule := range policy.Rules {
+		rules = append(rules, map[string]interface{}{
+			"label":     rule.Label,
+			"retention": rule.Rentention,
+		})
+	}
+	result := make(map[string]interface{})
+	result["name"] = policy.Name
+	result["archive_after_days"] = policy.ArchiveAfteDays
+	if policy.ObjectLock != "" {
+		objectLock = policy.ObjectLock
+	}
+	if objectLock != "NONE" {
+		result["object_lock"] = objectLock
+	}
+	if len(rules) != 0 {
+		result["policy_rules"] = []interface{}{map[string]interface{}{"rule": rules}}
+	}
+	return []interface{}{result}
+}
+
+// flattenCBSVolumes refreshes the volumes in the state with the volumes that are backed up. Volumes no longer
+// backed up are removed. Volumes backed up out of band are added on import, and when the resource manages a volume list,
+// so the drift is shown. They are not added when the volumes are backed up automatically.
+func flattenCBSVolumes(stateVolumes []interface{}, backupVolumes []cbsVolumeResult, res cbsWEResult, isImport bool) []interface{} {
+	backupVolumesByName := make(map[string]cbsVolumeResult)
+	for _, vol := range backupVolumes {
+		backupVolumesByName[vol.Name] = vol
+	}
+	result := make([]interface{}, 0, len(backupVolumes))
+	stateVolumeNames := make(map[string]bool)
+	for _, x := range stateVolumes {
+		volumeConfig := x.(map[string]interface{})
+		stateVolumeNames[volumeConfig["volume_name"].(string)] = true
+		vol, ok := backupVolumesByName[volumeConfig["volume_name"].(string)]
+		if !ok {
+			log.Printf("volume %s is no longer backed up", volumeConfig["volume_name"].(string))
+			continue
+		}
+		volume := make(map[string]interface{})
+		volume["volume_name"] = vol.Name
+		volume["mode"] = volumeConfig["mode"]
+		if volumeConfig["mode"].(string) != "" && vol.Mode != "" {
+			volume["mode"] = vol.Mode
+		}
+		volume["backup_policy"] = volumeConfig["backup_policy"]
+		if volumeConfig["backup_policy"].(*schema.Set).Len() != 0 && vol.BackupPolicy.Name != "" {
+			volume["backup_policy"] = flattenBackupPolicy(vol.BackupPolicy, res.ObjectLock)
+		}
+		result = append(result, volume)
+	}
+	if res.AutoBackupEnabled || (!isImport && len(stateVolumes) == 0) {
+		return result
+	}
+	for _, vol := range backupVolumes {
+		if stateVolumeNames[vol.Name] {
+			continue
+		}
+		log.Printf("volume %s is backed up outside of this resource", vol.Name)
+		volume := make(map[string]interface{})
+		volume["volume_name"] = vol.Name
+		volume["mode"] = vol.Mode
+		if vol.BackupPolicy.Name != "" && vol.BackupPolicy.Name != res.BackupPolicy.Name {
+			volume["backup_policy"] = flattenBackupPolicy(vol.BackupPolicy, res.ObjectLock)
+		}
+		result = append(result, volume)
+	}
+	return result
+}
+
 func expandAws(awsParameterList *schema.Set) awsDetails {
 	var params awsDetails
 
@@ -0,0 +1,92 @@
+package cloudmanager
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestFlattenCBSVolumes(t *testing.T) {
+	policy := backupPolicyResult{Name: "policy1"}
+	backupVolumes := []cbsVolumeResult{
+		{Name: "vol1", Mode: "ADD", BackupPolicy: policy},
+		{Name: "vol2", Mode: "ADD", BackupPolicy: policy},
+	}
+	stateVolume := map[string]interface{}{
+		"volume_name":   "vol1",
+		"mode":          "",
+		"backup_policy": schema.NewSet(func(interface{}) int { return 0 }, nil),
+	}
+	cases := []struct {
+		name         string
+		stateVolumes []interface{}
+		res          cbsWEResult
+		isImport     bool
+		expected     []string
+	}{
+		{"import", nil, cbsWEResult{BackupPolicy: policy}, true, []string{"vol1", "vol2"}},
+		{"import with auto backup", nil, cbsWEResult{BackupPolicy: policy, AutoBackupEnabled: true}, true, []string{}},
+		{"out of band volume", []interface{}{stateVolume}, cbsWEResult{BackupPolicy: policy}, false, []string{"vol1", "vol2"}},
+		{"out of band volume with auto backup", []interface{}{stateVolume}, cbsWEResult{BackupPolicy: policy, AutoBackupEnabled: true}, false, []string{"vol1"}},
+		{"no managed volumes", nil, cbsWEResult{BackupPolicy: policy}, false, []string{}},
+	}
+	for _, c := range cases {
+		volumes := flattenCBSVolumes(c.stateVolumes, backupVolumes, c.res, c.isImport)
+		names := make([]string, 0, len(volumes))
+		for _, v := range volumes {
+			names = append(names, v.(map[string]interface{})["volume_name"].(string))
+		}
+		if len(names) != len(c.expected) {
+			t.Errorf("%s: expected volumes %v, got %v", c.name, c.expected, names)
+			continue
+		}
+		for i := range names {
+			if names[i] != c.expected[i] {
+				t.Errorf("%s: expected volumes %v, got %v", c.name, c.expected, names)
+				break
+			}
+		}
+	}
+}
+
+func TestResourceCBSImportPlan(t *testing.T) {
+	policy := backupPolicyResult{Name: "policy1", Rules: []ruleResult{{Label: "Daily", Rentention: "30"}}}
+	res := cbsWEResult{BackupPolicy: policy, ObjectLock: "NONE"}
+	backupVolumes := []cbsVolumeResult{{Name: "vol1", Mode: "ADD", BackupPolicy: policy}}
+
+	// the state as read on import
+	d := resourceCBS().Data(nil)
+	d.SetId("wepublicid")
+	d.Set("account_id", "account-1")
+	d.Set("working_environment_name", "cvo")
+	d.Set("client_id", "client")
+	d.Set("cloud_provider", "AWS")
+	d.Set("region", "us-east-1")
+	d.Set("bucket", "netapp-backup")
+	d.Set("ip_space", "Default")
+	d.Set("backup_policy", flattenBackupPolicy(res.BackupPolicy, res.ObjectLock))
+	d.Set("aws_cbs_parameters", []interface{}{map[string]interface{}{"aws_account_id": "123456789012", "archive_storage_class": ""}})
+	d.Set("volumes", flattenCBSVolumes(nil, backupVolumes, res, true))
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"account_id":               "account-1",
+		"working_environment_name": "cvo",
+		"client_id":                "client",
+		"cloud_provider":           "AWS",
+		"region":                   "us-east-1",
+		"aws_cbs_parameters":       []interface{}{map[string]interface{}{"aws_account_id": "123456789012"}},
+		"backup_policy": []interface{}{map[string]interface{}{
+			"name":         "policy1",
+			"policy_rules": []interface{}{map[string]interface{}{"rule": []interface{}{map[string]interface{}{"label": "Daily", "retention": "30"}}}},
+		}},
+		"volumes": []interface{}{map[string]interface{}{"volume_name": "vol1", "mode": "ADD"}},
+	})
+	diff, err := resourceCBS().Diff(d.State(), config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected an empty plan after import, got %#v", diff.Attributes)
+	}
+}
@@ -0,0 +1,357 @@
+package cloudmanager
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceCIFSShare() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCIFSShareCreate,
+		Read:   resourceCIFSShareRead,
+		Delete: resourceCIFSShareDelete,
+		Exists: resourceCIFSShareExists,
+		Update: resourceCIFSShareUpdate,
+		Importer: &schema.ResourceImporter{
+			State: resourceCIFSShareImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"volume_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The path of the share in the SVM namespace. Defaults to '/<volume_name>'. Use '/<volume_name>/<directory>' to share a sub-directory.",
+			},
+			"working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"svm_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"comment": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"access_based_enumeration": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"oplocks": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"continuously_available": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"acl": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"user_or_group": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"permission": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"no_access", "read", "change", "full_control"}, false),
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "windows",
+							ValidateFunc: validation.StringInSlice([]string{"windows", "unix_user", "unix_group"}, false),
+						},
+					},
+				},
+			},
+			"connector_ip": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"deployment_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"Standard", "Restricted"}, false),
+				Default:      "Standard",
+			},
+		},
+	}
+}
+
+func resourceCIFSShareCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Creating CIFS share: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+
+	request := cifsShareRequest{}
+	request.Name = d.Get("name").(string)
+	request.WorkingEnvironmentID = workingEnv.PublicID
+	request.SvmName = getSvmName(d, workingEnv)
+	if v, ok := d.GetOk("path"); ok {
+		request.Path = v.(string)
+	} else if v, ok := d.GetOk("volume_name"); ok {
+		request.Path = "/" + v.(string)
+	} else {
+		return fmt.Errorf("either path or volume_name is required for cifs share")
+	}
+	setCIFSShareProperties(d, &request)
+
+	err = client.createCIFSShare(request, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error creating CIFS share")
+		return err
+	}
+	d.SetId(request.Name)
+	d.Set("svm_name", request.SvmName)
+
+	return resourceCIFSShareRead(d, meta)
+}
+
+func resourceCIFSShareRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Reading CIFS share: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+	svm := getSvmName(d, workingEnv)
+	name := d.Get("name").(string)
+
+	share, err := client.getCIFSShare(workingEnv.PublicID, svm, name, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error reading CIFS share")
+		return err
+	}
+	if share.Name != name {
+		return fmt.Errorf("expected cifs share name %v, Response could not find", name)
+	}
+
+	if strings.Contains(d.Id(), ",") {
+		d.SetId(share.Name)
+		d.Set("working_environment_name", workingEnv.Name)
+	}
+	d.Set("svm_name", svm)
+	d.Set("path", share.Path)
+	d.Set("volume_name", share.VolumeName)
+	d.Set("comment", share.Comment)
+	d.Set("access_based_enumeration", share.AccessBasedEnumeration)
+	d.Set("oplocks", share.Oplocks)
+	d.Set("continuously_available", share.ContinuouslyAvailable)
+	acls := share.Acls
+	if d.Get("acl").(*schema.Set).Len() == 0 && isDefaultCIFSShareACL(acls) {
+		// ONTAP gives a share without ACL full control to Everyone, it is not drift from an empty acl
+		acls = nil
+	}
+	if err := d.Set("acl", flattenCIFSShareAcls(acls)); err != nil {
+		return fmt.Errorf("error reading cifs share acl: %s", err)
+	}
+
+	return nil
+}
+
+func resourceCIFSShareUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Updating CIFS share: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+
+	if d.HasChange("comment") || d.HasChange("access_based_enumeration") || d.HasChange("oplocks") ||
+		d.HasChange("continuously_available") || d.HasChange("acl") {
+		request := cifsShareRequest{}
+		request.Name = d.Get("name").(string)
+		request.WorkingEnvironmentID = workingEnv.PublicID
+		request.SvmName = getSvmName(d, workingEnv)
+		request.Path = d.Get("path").(string)
+		setCIFSShareProperties(d, &request)
+
+		err = client.updateCIFSShare(request, clientID, isSaas, connectorIP)
+		if err != nil {
+			log.Print("Error updating CIFS share")
+			return err
+		}
+	}
+
+	return resourceCIFSShareRead(d, meta)
+}
+
+func resourceCIFSShareDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Deleting CIFS share: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+
+	err = client.deleteCIFSShare(workingEnv.PublicID, getSvmName(d, workingEnv), d.Get("name").(string), clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error deleting CIFS share")
+		return err
+	}
+	return nil
+}
+
+func resourceCIFSShareExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	log.Printf("Checking existence of CIFS share: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return false, err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return false, fmt.Errorf("cannot find working environment")
+	}
+
+	name := d.Get("name").(string)
+	share, err := client.getCIFSShare(workingEnv.PublicID, getSvmName(d, workingEnv), name, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error getting CIFS share")
+		return false, err
+	}
+	if share.Name != name {
+		d.SetId("")
+		return false, nil
+	}
+	return true, nil
+}
+
+func resourceCIFSShareImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ",")
+	if parts[0] != "Standard" && parts[0] != "Restricted" {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong option for deployment_mode: %s, options for deployment_mode are 'Standard' and 'Restricted'", parts[0])
+	}
+
+	if parts[0] == "Standard" && len(parts) != 5 {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'deployment_mode,client_id,working_environment_name,svm_name,name'", d.Id())
+	}
+
+	if parts[0] == "Restricted" && len(parts) != 7 {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'deployment_mode,client_id,working_environment_name,svm_name,name,tenant_id,connector_ip'", d.Id())
+	}
+
+	d.Set("deployment_mode", parts[0])
+	d.Set("client_id", parts[1])
+	d.Set("working_environment_name", parts[2])
+	d.Set("svm_name", parts[3])
+	d.Set("name", parts[4])
+	if parts[0] == "Restricted" {
+		d.Set("tenant_id", parts[5])
+		d.Set("connector_ip", parts[6])
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// setCIFSShareProperties sets the updatable share properties and ACLs from the configuration.
+func setCIFSShareProperties(d *schema.ResourceData, request *cifsShareRequest) {
+	request.Comment = d.Get("comment").(string)
+	request.AccessBasedEnumeration = d.Get("access_based_enumeration").(bool)
+	request.Oplocks = d.Get("oplocks").(bool)
+	request.ContinuouslyAvailable = d.Get("continuously_available").(bool)
+	request.Acls = []cifsShareACL{}
+	if v, ok := d.GetOk("acl"); ok {
+		for _, x := range v.(*schema.Set).List() {
+			acl := x.(map[string]interface{})
+			request.Acls = append(request.Acls, cifsShareACL{
+				UserOrGroup: acl["user_or_group"].(string),
+				Permission:  acl["permission"].(string),
+				Type:        acl["type"].(string),
+			})
+		}
+	}
+}
+
+// isDefaultCIFSShareACL returns true if the ACL only has the entry ONTAP creates for a new share
+func isDefaultCIFSShareACL(acls []cifsShareACL) bool {
+	return len(acls) == 1 && strings.EqualFold(acls[0].UserOrGroup, "Everyone") && acls[0].Permission == "full_control"
+}
+
+func flattenCIFSShareAcls(acls []cifsShareACL) []interface{} {
+	result := make([]interface{}, 0, len(acls))
+	for _, acl := range acls {
+		entry := make(map[string]interface{})
+		entry["user_or_group"] = acl.UserOrGroup
+		entry["permission"] = acl.Permission
+		entry["type"] = acl.Type
+		result = append(result, entry)
+	}
+	return result
+}
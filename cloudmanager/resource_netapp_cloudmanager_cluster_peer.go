@@ -0,0 +1,276 @@
+package cloudmanager
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceClusterPeer() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceClusterPeerCreate,
+		Read:   resourceClusterPeerRead,
+		Delete: resourceClusterPeerDelete,
+		Exists: resourceClusterPeerExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceClusterPeerImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"source_working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"source_working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"destination_working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"destination_working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"source_intercluster_lif_ips": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"destination_intercluster_lif_ips": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"peer_cluster_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"availability": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"connector_ip": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"deployment_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"Standard", "Restricted"}, false),
+				Default:      "Standard",
+			},
+		},
+	}
+}
+
+func resourceClusterPeerCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Creating cluster peer: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	sourceWEInfo, destWEInfo, err := client.getWorkingEnvironmentDetailForSnapMirror(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Cannot find working environment")
+		return err
+	}
+
+	peer, err := client.getClusterPeer(sourceWEInfo.PublicID, destWEInfo.PublicID, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error reading cluster peer")
+		return err
+	}
+	if peer.PeerClusterName != "" {
+		return fmt.Errorf("working environment %s is already peered with %s, import it into this resource to manage the cluster peer", sourceWEInfo.PublicID, destWEInfo.PublicID)
+	}
+
+	request := clusterPeerRequest{}
+	request.WorkingEnvironmentID = sourceWEInfo.PublicID
+	request.PeerWorkingEnvironmentID = destWEInfo.PublicID
+	request.InterclusterLifIps = expandInterclusterLifIps(d.Get("source_intercluster_lif_ips").([]interface{}))
+	request.PeerInterclusterLifIps = expandInterclusterLifIps(d.Get("destination_intercluster_lif_ips").([]interface{}))
+	if len(request.InterclusterLifIps) == 0 || len(request.PeerInterclusterLifIps) == 0 {
+		lifs, err := client.getInterclusterlifs(buildClusterPeerSnapMirrorRequest(sourceWEInfo.PublicID, destWEInfo.PublicID), clientID, isSaas, connectorIP)
+		if err != nil {
+			log.Print("intercluster-lifs reading failed")
+			return err
+		}
+		sourceLifIP, destinationLifIP, err := getInterclusterLifIps(lifs, sourceWEInfo.PublicID, destWEInfo.PublicID)
+		if err != nil {
+			return err
+		}
+		if len(request.InterclusterLifIps) == 0 {
+			request.InterclusterLifIps = []string{sourceLifIP}
+		}
+		if len(request.PeerInterclusterLifIps) == 0 {
+			request.PeerInterclusterLifIps = []string{destinationLifIP}
+		}
+	}
+
+	err = client.createClusterPeer(request, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error creating cluster peer")
+		return err
+	}
+	d.SetId(request.WorkingEnvironmentID + ":" + request.PeerWorkingEnvironmentID)
+	d.Set("source_intercluster_lif_ips", request.InterclusterLifIps)
+	d.Set("destination_intercluster_lif_ips", request.PeerInterclusterLifIps)
+
+	return resourceClusterPeerRead(d, meta)
+}
+
+func resourceClusterPeerRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Reading cluster peer: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	sourceWEInfo, destWEInfo, err := client.getWorkingEnvironmentDetailForSnapMirror(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Cannot find working environment")
+		return err
+	}
+
+	peer, err := client.getClusterPeer(sourceWEInfo.PublicID, destWEInfo.PublicID, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error reading cluster peer")
+		return err
+	}
+	if peer.PeerClusterName == "" {
+		return fmt.Errorf("expected cluster peer of working environment %v to %v, Response could not find", sourceWEInfo.PublicID, destWEInfo.PublicID)
+	}
+
+	if strings.Contains(d.Id(), ",") {
+		d.SetId(sourceWEInfo.PublicID + ":" + destWEInfo.PublicID)
+	}
+	d.Set("peer_cluster_name", peer.PeerClusterName)
+	d.Set("availability", peer.Availability)
+	if peer.Availability != "" && peer.Availability != "available" {
+		log.Printf("[WARN] cluster peer %s of working environment %s is %s", peer.PeerClusterName, sourceWEInfo.PublicID, peer.Availability)
+	}
+
+	return nil
+}
+
+func resourceClusterPeerDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Deleting cluster peer: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	sourceWEInfo, _, err := client.getWorkingEnvironmentDetailForSnapMirror(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Cannot find working environment")
+		return err
+	}
+
+	err = client.deleteClusterPeer(sourceWEInfo.PublicID, d.Get("peer_cluster_name").(string), clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error deleting cluster peer")
+		return err
+	}
+	return nil
+}
+
+func resourceClusterPeerExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	log.Printf("Checking existence of cluster peer: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return false, err
+	}
+
+	sourceWEInfo, destWEInfo, err := client.getWorkingEnvironmentDetailForSnapMirror(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Cannot find working environment")
+		return false, err
+	}
+
+	peer, err := client.getClusterPeer(sourceWEInfo.PublicID, destWEInfo.PublicID, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error getting cluster peer")
+		return false, err
+	}
+	if peer.PeerClusterName == "" {
+		d.SetId("")
+		return false, nil
+	}
+	return true, nil
+}
+
+func resourceClusterPeerImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ",")
+	if parts[0] != "Standard" && parts[0] != "Restricted" {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong option for deployment_mode: %s, options for deployment_mode are 'Standard' and 'Restricted'", parts[0])
+	}
+
+	if parts[0] == "Standard" && len(parts) != 4 {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'deployment_mode,client_id,source_working_environment_name,destination_working_environment_name'", d.Id())
+	}
+
+	if parts[0] == "Restricted" && len(parts) != 6 {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'deployment_mode,client_id,source_working_environment_name,destination_working_environment_name,tenant_id,connector_ip'", d.Id())
+	}
+
+	d.Set("deployment_mode", parts[0])
+	d.Set("client_id", parts[1])
+	d.Set("source_working_environment_name", parts[2])
+	d.Set("destination_working_environment_name", parts[3])
+	if parts[0] == "Restricted" {
+		d.Set("tenant_id", parts[4])
+		d.Set("connector_ip", parts[5])
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// buildClusterPeerSnapMirrorRequest returns the replication request used to look up the intercluster LIFs of both working environments
+func buildClusterPeerSnapMirrorRequest(sourceWorkingEnvironmentID string, destinationWorkingEnvironmentID string) snapMirrorRequest {
+	snapMirror := snapMirrorRequest{}
+	snapMirror.ReplicationRequest.SourceWorkingEnvironmentID = sourceWorkingEnvironmentID
+	if strings.HasPrefix(destinationWorkingEnvironmentID, "fs-") {
+		snapMirror.ReplicationRequest.DestinationFsxID = destinationWorkingEnvironmentID
+	} else {
+		snapMirror.ReplicationRequest.DestinationWorkingEnvironmentID = destinationWorkingEnvironmentID
+	}
+	return snapMirror
+}
@@ -0,0 +1,288 @@
+package cloudmanager
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceCronSchedule() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCronScheduleCreate,
+		Read:   resourceCronScheduleRead,
+		Delete: resourceCronScheduleDelete,
+		Exists: resourceCronScheduleExists,
+		Update: resourceCronScheduleUpdate,
+		Importer: &schema.ResourceImporter{
+			State: resourceCronScheduleImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"minutes": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(0, 59),
+				},
+			},
+			"hours": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(0, 23),
+				},
+			},
+			"days_of_month": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(1, 31),
+				},
+			},
+			"weekdays": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(0, 6),
+				},
+			},
+			"months": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(1, 12),
+				},
+			},
+			"connector_ip": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"deployment_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"Standard", "Restricted"}, false),
+				Default:      "Standard",
+			},
+		},
+	}
+}
+
+func resourceCronScheduleCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Creating cron schedule: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+
+	request := buildCronScheduleRequest(d, workingEnv.PublicID)
+	err = client.createCronSchedule(request, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error creating cron schedule")
+		return err
+	}
+	d.SetId(request.Name)
+
+	return resourceCronScheduleRead(d, meta)
+}
+
+func resourceCronScheduleRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Reading cron schedule: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+	name := d.Get("name").(string)
+
+	schedule, err := client.getCronSchedule(workingEnv.PublicID, name, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error reading cron schedule")
+		return err
+	}
+	if schedule.Name != name {
+		return fmt.Errorf("expected cron schedule name %v, Response could not find", name)
+	}
+
+	if strings.Contains(d.Id(), ",") {
+		d.SetId(schedule.Name)
+		d.Set("working_environment_name", workingEnv.Name)
+	}
+	d.Set("minutes", schedule.Minutes)
+	d.Set("hours", schedule.Hours)
+	d.Set("days_of_month", schedule.DaysOfMonth)
+	d.Set("weekdays", schedule.Weekdays)
+	d.Set("months", schedule.Months)
+
+	return nil
+}
+
+func resourceCronScheduleUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Updating cron schedule: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+
+	if d.HasChange("minutes") || d.HasChange("hours") || d.HasChange("days_of_month") || d.HasChange("weekdays") || d.HasChange("months") {
+		request := buildCronScheduleRequest(d, workingEnv.PublicID)
+		err = client.updateCronSchedule(request, clientID, isSaas, connectorIP)
+		if err != nil {
+			log.Print("Error updating cron schedule")
+			return err
+		}
+	}
+
+	return resourceCronScheduleRead(d, meta)
+}
+
+func resourceCronScheduleDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Deleting cron schedule: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+
+	err = client.deleteCronSchedule(workingEnv.PublicID, d.Get("name").(string), clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error deleting cron schedule")
+		return err
+	}
+	return nil
+}
+
+func resourceCronScheduleExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	log.Printf("Checking existence of cron schedule: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return false, err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return false, fmt.Errorf("cannot find working environment")
+	}
+
+	name := d.Get("name").(string)
+	schedule, err := client.getCronSchedule(workingEnv.PublicID, name, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error getting cron schedule")
+		return false, err
+	}
+	if schedule.Name != name {
+		d.SetId("")
+		return false, nil
+	}
+	return true, nil
+}
+
+func resourceCronScheduleImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ",")
+	if parts[0] != "Standard" && parts[0] != "Restricted" {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong option for deployment_mode: %s, options for deployment_mode are 'Standard' and 'Restricted'", parts[0])
+	}
+
+	if parts[0] == "Standard" && len(parts) != 4 {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'deployment_mode,client_id,working_environment_name,name'", d.Id())
+	}
+
+	if parts[0] == "Restricted" && len(parts) != 6 {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'deployment_mode,client_id,working_environment_name,name,tenant_id,connector_ip'", d.Id())
+	}
+
+	d.Set("deployment_mode", parts[0])
+	d.Set("client_id", parts[1])
+	d.Set("working_environment_name", parts[2])
+	d.Set("name", parts[3])
+	if parts[0] == "Restricted" {
+		d.Set("tenant_id", parts[4])
+		d.Set("connector_ip", parts[5])
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func buildCronScheduleRequest(d *schema.ResourceData, workingEnvironmentID string) cronScheduleRequest {
+	return cronScheduleRequest{
+		Name:                 d.Get("name").(string),
+		Minutes:              expandCronScheduleField(d.Get("minutes").([]interface{})),
+		Hours:                expandCronScheduleField(d.Get("hours").([]interface{})),
+		DaysOfMonth:          expandCronScheduleField(d.Get("days_of_month").([]interface{})),
+		Weekdays:             expandCronScheduleField(d.Get("weekdays").([]interface{})),
+		Months:               expandCronScheduleField(d.Get("months").([]interface{})),
+		WorkingEnvironmentID: workingEnvironmentID,
+	}
+}
+
+func expandCronScheduleField(values []interface{}) []int {
+	result := make([]int, 0, len(values))
+	for _, v := range values {
+		result = append(result, v.(int))
+	}
+	return result
+}
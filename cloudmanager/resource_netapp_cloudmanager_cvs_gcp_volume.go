@@ -290,7 +290,11 @@ func resourceCVSGCPVolumeCreate(d *schema.ResourceData, meta interface{}) error
 
 	// // size in 1 GiB increments, api takes in bytes only
 	// volume.Size = d.Get("size").(int) * GiBToBytes
-	volume.Size = math.Round(convertSizeUnit(d.Get("size").(float64), d.Get("size_unit").(string), "B")*10) / 10
+	size, err := convertSizeUnit(d.Get("size").(float64), d.Get("size_unit").(string), "B")
+	if err != nil {
+		return err
+	}
+	volume.Size = math.Round(size*10) / 10
 	if v, ok := d.GetOk("service_level"); ok {
 		volume.ServiceLevel = v.(string)
 	}
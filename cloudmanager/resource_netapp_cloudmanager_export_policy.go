@@ -0,0 +1,320 @@
+package cloudmanager
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceExportPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceExportPolicyCreate,
+		Read:   resourceExportPolicyRead,
+		Delete: resourceExportPolicyDelete,
+		Exists: resourceExportPolicyExists,
+		Update: resourceExportPolicyUpdate,
+		Importer: &schema.ResourceImporter{
+			State: resourceExportPolicyImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"svm_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"rule": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Description: "Export policy rules. Rules are evaluated in the order they are listed.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"client_match": {
+							Type:     schema.TypeList,
+							Required: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"access_control": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"readonly", "readwrite", "none"}, false),
+						},
+						"nfs_version": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"super_user": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"rule_index": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"connector_ip": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"deployment_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"Standard", "Restricted"}, false),
+				Default:      "Standard",
+			},
+		},
+	}
+}
+
+func resourceExportPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Creating export policy: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+
+	request := exportPolicyRequest{}
+	request.Name = d.Get("name").(string)
+	request.WorkingEnvironmentID = workingEnv.PublicID
+	request.SvmName = getSvmName(d, workingEnv)
+	request.Rules = expandExportPolicyRules(d.Get("rule").([]interface{}))
+
+	err = client.createExportPolicy(request, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error creating export policy")
+		return err
+	}
+	d.SetId(request.Name)
+	d.Set("svm_name", request.SvmName)
+
+	return resourceExportPolicyRead(d, meta)
+}
+
+func resourceExportPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Reading export policy: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+	svm := getSvmName(d, workingEnv)
+	name := d.Get("name").(string)
+
+	policy, err := client.getExportPolicy(workingEnv.PublicID, svm, name, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error reading export policy")
+		return err
+	}
+	if policy.Name != name {
+		return fmt.Errorf("expected export policy name %v, Response could not find", name)
+	}
+
+	if strings.Contains(d.Id(), ",") {
+		d.SetId(policy.Name)
+		d.Set("working_environment_name", workingEnv.Name)
+	}
+	d.Set("svm_name", svm)
+	if err := d.Set("rule", flattenExportPolicyRules(policy.Rules)); err != nil {
+		return fmt.Errorf("error reading export policy rule: %s", err)
+	}
+
+	return nil
+}
+
+func resourceExportPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Updating export policy: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+
+	if d.HasChange("rule") {
+		request := exportPolicyRequest{}
+		request.Name = d.Get("name").(string)
+		request.WorkingEnvironmentID = workingEnv.PublicID
+		request.SvmName = getSvmName(d, workingEnv)
+		request.Rules = expandExportPolicyRules(d.Get("rule").([]interface{}))
+
+		err = client.updateExportPolicy(request, clientID, isSaas, connectorIP)
+		if err != nil {
+			log.Print("Error updating export policy")
+			return err
+		}
+	}
+
+	return resourceExportPolicyRead(d, meta)
+}
+
+func resourceExportPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Deleting export policy: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+
+	err = client.deleteExportPolicy(workingEnv.PublicID, getSvmName(d, workingEnv), d.Get("name").(string), clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error deleting export policy")
+		return err
+	}
+	return nil
+}
+
+func resourceExportPolicyExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	log.Printf("Checking existence of export policy: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return false, err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return false, fmt.Errorf("cannot find working environment")
+	}
+
+	name := d.Get("name").(string)
+	policy, err := client.getExportPolicy(workingEnv.PublicID, getSvmName(d, workingEnv), name, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error getting export policy")
+		return false, err
+	}
+	if policy.Name != name {
+		d.SetId("")
+		return false, nil
+	}
+	return true, nil
+}
+
+func resourceExportPolicyImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ",")
+	if parts[0] != "Standard" && parts[0] != "Restricted" {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong option for deployment_mode: %s, options for deployment_mode are 'Standard' and 'Restricted'", parts[0])
+	}
+
+	if parts[0] == "Standard" && len(parts) != 5 {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'deployment_mode,client_id,working_environment_name,svm_name,name'", d.Id())
+	}
+
+	if parts[0] == "Restricted" && len(parts) != 7 {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'deployment_mode,client_id,working_environment_name,svm_name,name,tenant_id,connector_ip'", d.Id())
+	}
+
+	d.Set("deployment_mode", parts[0])
+	d.Set("client_id", parts[1])
+	d.Set("working_environment_name", parts[2])
+	d.Set("svm_name", parts[3])
+	d.Set("name", parts[4])
+	if parts[0] == "Restricted" {
+		d.Set("tenant_id", parts[5])
+		d.Set("connector_ip", parts[6])
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// expandExportPolicyRules converts the rule list to API rules. The rule index follows the list order.
+func expandExportPolicyRules(rules []interface{}) []ExportPolicyRule {
+	result := make([]ExportPolicyRule, 0, len(rules))
+	for i, v := range rules {
+		rule := v.(map[string]interface{})
+		policyRule := ExportPolicyRule{}
+		policyRule.Index = int32(i + 1)
+		for _, ip := range rule["client_match"].([]interface{}) {
+			policyRule.Ips = append(policyRule.Ips, ip.(string))
+		}
+		policyRule.RuleAccessControl = rule["access_control"].(string)
+		policyRule.SuperUser = rule["super_user"].(bool)
+		if versions, ok := rule["nfs_version"].(*schema.Set); ok {
+			for _, version := range versions.List() {
+				policyRule.NfsVersion = append(policyRule.NfsVersion, version.(string))
+			}
+		}
+		result = append(result, policyRule)
+	}
+	return result
+}
+
+func flattenExportPolicyRules(rules []ExportPolicyRule) []interface{} {
+	result := make([]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		policyRule := make(map[string]interface{})
+		policyRule["client_match"] = rule.Ips
+		policyRule["access_control"] = rule.RuleAccessControl
+		policyRule["super_user"] = rule.SuperUser
+		policyRule["nfs_version"] = rule.NfsVersion
+		policyRule["rule_index"] = int(rule.Index)
+		result = append(result, policyRule)
+	}
+	return result
+}
@@ -0,0 +1,356 @@
+package cloudmanager
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceIgroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceIgroupCreate,
+		Read:   resourceIgroupRead,
+		Delete: resourceIgroupDelete,
+		Exists: resourceIgroupExists,
+		Update: resourceIgroupUpdate,
+		Importer: &schema.ResourceImporter{
+			State: resourceIgroupImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"svm_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"os_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"igroup_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "iscsi",
+				ValidateFunc: validation.StringInSlice([]string{"iscsi", "fcp", "mixed"}, false),
+			},
+			"portsets": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"initiator": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"alias": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"iqn": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"connector_ip": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"deployment_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"Standard", "Restricted"}, false),
+				Default:      "Standard",
+			},
+		},
+	}
+}
+
+func resourceIgroupCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Creating igroup: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+
+	request := igroupRequest{}
+	request.IgroupName = d.Get("name").(string)
+	request.WorkingEnvironmentID = workingEnv.PublicID
+	request.SvmName = getSvmName(d, workingEnv)
+	request.OsType = d.Get("os_type").(string)
+	request.IgroupType = d.Get("igroup_type").(string)
+	request.Portsets = expandIgroupPortsets(d.Get("portsets").(*schema.Set))
+	initiators := expandInitiator(d.Get("initiator").(*schema.Set))
+	err = client.ensureInitiators(initiators, workingEnv.PublicID, workingEnv.WorkingEnvironmentType, request.SvmName, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error creating initiators")
+		return err
+	}
+	request.Initiators = getInitiatorIqns(initiators)
+
+	err = client.createIgroup(request, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error creating igroup")
+		return err
+	}
+	d.SetId(request.IgroupName)
+	d.Set("svm_name", request.SvmName)
+
+	return resourceIgroupRead(d, meta)
+}
+
+func resourceIgroupRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Reading igroup: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+	svm := getSvmName(d, workingEnv)
+	name := d.Get("name").(string)
+
+	ig, err := client.getIgroup(workingEnv.PublicID, workingEnv.WorkingEnvironmentType, svm, name, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error reading igroup")
+		return err
+	}
+	if ig.IgroupName != name {
+		return fmt.Errorf("expected igroup name %v, Response could not find", name)
+	}
+
+	if strings.Contains(d.Id(), ",") {
+		d.SetId(ig.IgroupName)
+		d.Set("working_environment_name", workingEnv.Name)
+	}
+	d.Set("svm_name", svm)
+	d.Set("os_type", ig.OsType)
+	if ig.IgroupType != "" {
+		d.Set("igroup_type", ig.IgroupType)
+	}
+	d.Set("portsets", flattenIgroupPortsets(ig))
+
+	getAll := initiator{}
+	getAll.WorkingEnvironmentID = workingEnv.PublicID
+	getAll.WorkingEnvironmentType = workingEnv.WorkingEnvironmentType
+	known, err := client.getInitiator(getAll, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error reading initiators")
+		return err
+	}
+	if err := d.Set("initiator", flattenIgroupInitiators(ig.Initiators, known)); err != nil {
+		return fmt.Errorf("error reading igroup initiator: %s", err)
+	}
+
+	return nil
+}
+
+func resourceIgroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Updating igroup: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+
+	if d.HasChange("initiator") || d.HasChange("portsets") {
+		request := igroupRequest{}
+		request.IgroupName = d.Get("name").(string)
+		request.WorkingEnvironmentID = workingEnv.PublicID
+		request.SvmName = getSvmName(d, workingEnv)
+		request.OsType = d.Get("os_type").(string)
+		request.IgroupType = d.Get("igroup_type").(string)
+		request.Portsets = expandIgroupPortsets(d.Get("portsets").(*schema.Set))
+		initiators := expandInitiator(d.Get("initiator").(*schema.Set))
+		err = client.ensureInitiators(initiators, workingEnv.PublicID, workingEnv.WorkingEnvironmentType, request.SvmName, clientID, isSaas, connectorIP)
+		if err != nil {
+			log.Print("Error creating initiators")
+			return err
+		}
+		request.Initiators = getInitiatorIqns(initiators)
+
+		err = client.updateIgroup(request, clientID, isSaas, connectorIP)
+		if err != nil {
+			log.Print("Error updating igroup")
+			return err
+		}
+	}
+
+	return resourceIgroupRead(d, meta)
+}
+
+func resourceIgroupDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Deleting igroup: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+
+	err = client.deleteIgroup(workingEnv.PublicID, getSvmName(d, workingEnv), d.Get("name").(string), clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error deleting igroup")
+		return err
+	}
+	return nil
+}
+
+func resourceIgroupExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	log.Printf("Checking existence of igroup: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return false, err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return false, fmt.Errorf("cannot find working environment")
+	}
+
+	name := d.Get("name").(string)
+	ig, err := client.getIgroup(workingEnv.PublicID, workingEnv.WorkingEnvironmentType, getSvmName(d, workingEnv), name, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error getting igroup")
+		return false, err
+	}
+	if ig.IgroupName != name {
+		d.SetId("")
+		return false, nil
+	}
+	return true, nil
+}
+
+func resourceIgroupImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ",")
+	if parts[0] != "Standard" && parts[0] != "Restricted" {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong option for deployment_mode: %s, options for deployment_mode are 'Standard' and 'Restricted'", parts[0])
+	}
+
+	if parts[0] == "Standard" && len(parts) != 5 {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'deployment_mode,client_id,working_environment_name,svm_name,name'", d.Id())
+	}
+
+	if parts[0] == "Restricted" && len(parts) != 7 {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'deployment_mode,client_id,working_environment_name,svm_name,name,tenant_id,connector_ip'", d.Id())
+	}
+
+	d.Set("deployment_mode", parts[0])
+	d.Set("client_id", parts[1])
+	d.Set("working_environment_name", parts[2])
+	d.Set("svm_name", parts[3])
+	d.Set("name", parts[4])
+	if parts[0] == "Restricted" {
+		d.Set("tenant_id", parts[5])
+		d.Set("connector_ip", parts[6])
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func getInitiatorIqns(initiators []initiator) []string {
+	iqns := make([]string, 0, len(initiators))
+	for _, ini := range initiators {
+		iqns = append(iqns, ini.Iqn)
+	}
+	return iqns
+}
+
+// flattenIgroupInitiators builds the initiator blocks of an igroup, the alias is taken from the known initiators
+func flattenIgroupInitiators(iqns []string, known []initiator) []interface{} {
+	result := make([]interface{}, 0, len(iqns))
+	for _, iqn := range iqns {
+		entry := make(map[string]interface{})
+		entry["iqn"] = iqn
+		entry["alias"] = ""
+		for _, ini := range known {
+			if ini.Iqn == iqn {
+				entry["alias"] = ini.AliasName
+				break
+			}
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+func expandIgroupPortsets(set *schema.Set) []string {
+	portsets := make([]string, 0, set.Len())
+	for _, v := range set.List() {
+		portsets = append(portsets, v.(string))
+	}
+	return portsets
+}
+
+// flattenIgroupPortsets returns the portsets bound to the igroup, older APIs only return a single portset name
+func flattenIgroupPortsets(ig igroup) []string {
+	if len(ig.Portsets) != 0 {
+		return ig.Portsets
+	}
+	if ig.PortsetName != "" {
+		return []string{ig.PortsetName}
+	}
+	return []string{}
+}
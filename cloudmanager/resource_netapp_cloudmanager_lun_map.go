@@ -0,0 +1,236 @@
+package cloudmanager
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceLunMap() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceLunMapCreate,
+		Read:   resourceLunMapRead,
+		Delete: resourceLunMapDelete,
+		Exists: resourceLunMapExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceLunMapImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"volume_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"igroup_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"lun_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"lun_path": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"svm_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"connector_ip": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"deployment_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"Standard", "Restricted"}, false),
+				Default:      "Standard",
+			},
+		},
+	}
+}
+
+func resourceLunMapCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Creating lun map: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+
+	request := lunMapRequest{}
+	request.WorkingEnvironmentID = workingEnv.PublicID
+	request.SvmName = getSvmName(d, workingEnv)
+	request.VolumeName = d.Get("volume_name").(string)
+	request.IgroupName = d.Get("igroup_name").(string)
+	if v, ok := d.GetOkExists("lun_id"); ok {
+		request.LunID = v.(int)
+	}
+
+	err = client.createLunMap(request, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error creating lun map")
+		return err
+	}
+	d.SetId(request.VolumeName + ":" + request.IgroupName)
+	d.Set("svm_name", request.SvmName)
+
+	return resourceLunMapRead(d, meta)
+}
+
+func resourceLunMapRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Reading lun map: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+	svm := getSvmName(d, workingEnv)
+	volumeName := d.Get("volume_name").(string)
+	igroupName := d.Get("igroup_name").(string)
+
+	lunMap, err := client.getLunMap(workingEnv.PublicID, svm, volumeName, igroupName, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error reading lun map")
+		return err
+	}
+	if lunMap.IgroupName != igroupName {
+		return fmt.Errorf("expected lun map of volume %v to igroup %v, Response could not find", volumeName, igroupName)
+	}
+
+	if strings.Contains(d.Id(), ",") {
+		d.SetId(volumeName + ":" + igroupName)
+		d.Set("working_environment_name", workingEnv.Name)
+	}
+	d.Set("svm_name", svm)
+	d.Set("lun_id", lunMap.LunID)
+	d.Set("lun_path", lunMap.LunPath)
+
+	return nil
+}
+
+func resourceLunMapDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Deleting lun map: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+
+	err = client.deleteLunMap(workingEnv.PublicID, getSvmName(d, workingEnv), d.Get("volume_name").(string), d.Get("igroup_name").(string), clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error deleting lun map")
+		return err
+	}
+	return nil
+}
+
+func resourceLunMapExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	log.Printf("Checking existence of lun map: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return false, err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return false, fmt.Errorf("cannot find working environment")
+	}
+
+	igroupName := d.Get("igroup_name").(string)
+	lunMap, err := client.getLunMap(workingEnv.PublicID, getSvmName(d, workingEnv), d.Get("volume_name").(string), igroupName, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error getting lun map")
+		return false, err
+	}
+	if lunMap.IgroupName != igroupName {
+		d.SetId("")
+		return false, nil
+	}
+	return true, nil
+}
+
+func resourceLunMapImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ",")
+	if parts[0] != "Standard" && parts[0] != "Restricted" {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong option for deployment_mode: %s, options for deployment_mode are 'Standard' and 'Restricted'", parts[0])
+	}
+
+	if parts[0] == "Standard" && len(parts) != 6 {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'deployment_mode,client_id,working_environment_name,svm_name,volume_name,igroup_name'", d.Id())
+	}
+
+	if parts[0] == "Restricted" && len(parts) != 8 {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'deployment_mode,client_id,working_environment_name,svm_name,volume_name,igroup_name,tenant_id,connector_ip'", d.Id())
+	}
+
+	d.Set("deployment_mode", parts[0])
+	d.Set("client_id", parts[1])
+	d.Set("working_environment_name", parts[2])
+	d.Set("svm_name", parts[3])
+	d.Set("volume_name", parts[4])
+	d.Set("igroup_name", parts[5])
+	if parts[0] == "Restricted" {
+		d.Set("tenant_id", parts[6])
+		d.Set("connector_ip", parts[7])
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,278 @@
+package cloudmanager
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceQtree() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceQtreeCreate,
+		Read:   resourceQtreeRead,
+		Delete: resourceQtreeDelete,
+		Exists: resourceQtreeExists,
+		Update: resourceQtreeUpdate,
+		Importer: &schema.ResourceImporter{
+			State: resourceQtreeImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"volume_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"svm_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"security_style": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"unix", "ntfs", "mixed"}, false),
+			},
+			"export_policy_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"oplocks": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"connector_ip": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"deployment_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"Standard", "Restricted"}, false),
+				Default:      "Standard",
+			},
+		},
+	}
+}
+
+func resourceQtreeCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Creating qtree: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+
+	request := qtreeRequest{}
+	request.Name = d.Get("name").(string)
+	request.WorkingEnvironmentID = workingEnv.PublicID
+	request.SvmName = getSvmName(d, workingEnv)
+	request.VolumeName = d.Get("volume_name").(string)
+	if v, ok := d.GetOk("security_style"); ok {
+		request.SecurityStyle = v.(string)
+	}
+	if v, ok := d.GetOk("export_policy_name"); ok {
+		request.ExportPolicyName = v.(string)
+	}
+	request.Oplocks = d.Get("oplocks").(bool)
+
+	err = client.createQtree(request, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error creating qtree")
+		return err
+	}
+	d.SetId(request.Name)
+	d.Set("svm_name", request.SvmName)
+
+	return resourceQtreeRead(d, meta)
+}
+
+func resourceQtreeRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Reading qtree: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+	svm := getSvmName(d, workingEnv)
+	name := d.Get("name").(string)
+
+	qtree, err := client.getQtree(workingEnv.PublicID, svm, d.Get("volume_name").(string), name, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error reading qtree")
+		return err
+	}
+	if qtree.Name != name {
+		return fmt.Errorf("expected qtree name %v, Response could not find", name)
+	}
+
+	if strings.Contains(d.Id(), ",") {
+		d.SetId(qtree.Name)
+		d.Set("working_environment_name", workingEnv.Name)
+	}
+	d.Set("svm_name", svm)
+	d.Set("security_style", qtree.SecurityStyle)
+	d.Set("export_policy_name", qtree.ExportPolicyName)
+	d.Set("oplocks", qtree.Oplocks)
+
+	return nil
+}
+
+func resourceQtreeUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Updating qtree: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+
+	if d.HasChange("security_style") || d.HasChange("export_policy_name") || d.HasChange("oplocks") {
+		request := qtreeRequest{}
+		request.Name = d.Get("name").(string)
+		request.WorkingEnvironmentID = workingEnv.PublicID
+		request.SvmName = getSvmName(d, workingEnv)
+		request.VolumeName = d.Get("volume_name").(string)
+		request.SecurityStyle = d.Get("security_style").(string)
+		request.ExportPolicyName = d.Get("export_policy_name").(string)
+		request.Oplocks = d.Get("oplocks").(bool)
+
+		err = client.updateQtree(request, clientID, isSaas, connectorIP)
+		if err != nil {
+			log.Print("Error updating qtree")
+			return err
+		}
+	}
+
+	return resourceQtreeRead(d, meta)
+}
+
+func resourceQtreeDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Deleting qtree: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+
+	err = client.deleteQtree(workingEnv.PublicID, getSvmName(d, workingEnv), d.Get("volume_name").(string), d.Get("name").(string), clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error deleting qtree")
+		return err
+	}
+	return nil
+}
+
+func resourceQtreeExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	log.Printf("Checking existence of qtree: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return false, err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return false, fmt.Errorf("cannot find working environment")
+	}
+
+	name := d.Get("name").(string)
+	qtree, err := client.getQtree(workingEnv.PublicID, getSvmName(d, workingEnv), d.Get("volume_name").(string), name, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error getting qtree")
+		return false, err
+	}
+	if qtree.Name != name {
+		d.SetId("")
+		return false, nil
+	}
+	return true, nil
+}
+
+func resourceQtreeImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ",")
+	if parts[0] != "Standard" && parts[0] != "Restricted" {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong option for deployment_mode: %s, options for deployment_mode are 'Standard' and 'Restricted'", parts[0])
+	}
+
+	if parts[0] == "Standard" && len(parts) != 6 {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'deployment_mode,client_id,working_environment_name,svm_name,volume_name,name'", d.Id())
+	}
+
+	if parts[0] == "Restricted" && len(parts) != 8 {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'deployment_mode,client_id,working_environment_name,svm_name,volume_name,name,tenant_id,connector_ip'", d.Id())
+	}
+
+	d.Set("deployment_mode", parts[0])
+	d.Set("client_id", parts[1])
+	d.Set("working_environment_name", parts[2])
+	d.Set("svm_name", parts[3])
+	d.Set("volume_name", parts[4])
+	d.Set("name", parts[5])
+	if parts[0] == "Restricted" {
+		d.Set("tenant_id", parts[6])
+		d.Set("connector_ip", parts[7])
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,332 @@
+package cloudmanager
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceQuotaRule() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceQuotaRuleCreate,
+		Read:          resourceQuotaRuleRead,
+		Delete:        resourceQuotaRuleDelete,
+		Exists:        resourceQuotaRuleExists,
+		Update:        resourceQuotaRuleUpdate,
+		CustomizeDiff: resourceQuotaRuleCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: resourceQuotaRuleImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"tree", "user", "group"}, false),
+			},
+			"volume_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"qtree_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The qtree the rule applies to. For a user or group rule, an empty qtree applies the rule to the whole volume.",
+			},
+			"user_or_group": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The user or group the rule applies to. Only used with user and group rules, an empty value is the default rule.",
+			},
+			"working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"svm_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"disk_limit": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"soft_disk_limit": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"disk_limit_unit": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "GB",
+				ValidateFunc: validation.StringInSlice([]string{"KB", "MB", "GB", "TB"}, false),
+			},
+			"file_limit": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"soft_file_limit": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"connector_ip": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"deployment_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"Standard", "Restricted"}, false),
+				Default:      "Standard",
+			},
+		},
+	}
+}
+
+func resourceQuotaRuleCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Creating quota rule: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+
+	request := buildQuotaRuleRequest(d, workingEnv)
+	err = client.createQuotaRule(request, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error creating quota rule")
+		return err
+	}
+	d.SetId(fmt.Sprintf("%s:%s:%s", request.Type, request.QtreeName, request.UserOrGroup))
+	d.Set("svm_name", request.SvmName)
+
+	return resourceQuotaRuleRead(d, meta)
+}
+
+func resourceQuotaRuleRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Reading quota rule: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+	svm := getSvmName(d, workingEnv)
+	ruleType := d.Get("type").(string)
+	qtreeName := d.Get("qtree_name").(string)
+	userOrGroup := d.Get("user_or_group").(string)
+
+	rule, err := client.getQuotaRule(workingEnv.PublicID, svm, d.Get("volume_name").(string), ruleType, qtreeName, userOrGroup, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error reading quota rule")
+		return err
+	}
+	if rule.Type != ruleType {
+		return fmt.Errorf("expected %s quota rule on qtree '%s' for '%s', Response could not find", ruleType, qtreeName, userOrGroup)
+	}
+
+	if strings.Contains(d.Id(), ",") {
+		d.SetId(fmt.Sprintf("%s:%s:%s", rule.Type, rule.QtreeName, rule.UserOrGroup))
+		d.Set("working_environment_name", workingEnv.Name)
+		switch unit := strings.ToUpper(rule.DiskLimit.Unit); unit {
+		case "KB", "MB", "GB", "TB":
+			d.Set("disk_limit_unit", unit)
+		}
+	}
+	d.Set("svm_name", svm)
+	unit := d.Get("disk_limit_unit").(string)
+	d.Set("disk_limit", convertQuotaLimit(rule.DiskLimit, unit))
+	d.Set("soft_disk_limit", convertQuotaLimit(rule.SoftDiskLimit, unit))
+	d.Set("file_limit", rule.FileLimit)
+	d.Set("soft_file_limit", rule.SoftFileLimit)
+
+	return nil
+}
+
+func resourceQuotaRuleUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Updating quota rule: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+
+	if d.HasChange("disk_limit") || d.HasChange("soft_disk_limit") || d.HasChange("disk_limit_unit") ||
+		d.HasChange("file_limit") || d.HasChange("soft_file_limit") {
+		request := buildQuotaRuleRequest(d, workingEnv)
+		err = client.updateQuotaRule(request, clientID, isSaas, connectorIP)
+		if err != nil {
+			log.Print("Error updating quota rule")
+			return err
+		}
+	}
+
+	return resourceQuotaRuleRead(d, meta)
+}
+
+func resourceQuotaRuleDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Deleting quota rule: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+
+	err = client.deleteQuotaRule(workingEnv.PublicID, getSvmName(d, workingEnv), d.Get("volume_name").(string), d.Get("type").(string),
+		d.Get("qtree_name").(string), d.Get("user_or_group").(string), clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error deleting quota rule")
+		return err
+	}
+	return nil
+}
+
+func resourceQuotaRuleExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	log.Printf("Checking existence of quota rule: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return false, err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return false, fmt.Errorf("cannot find working environment")
+	}
+
+	ruleType := d.Get("type").(string)
+	rule, err := client.getQuotaRule(workingEnv.PublicID, getSvmName(d, workingEnv), d.Get("volume_name").(string), ruleType,
+		d.Get("qtree_name").(string), d.Get("user_or_group").(string), clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error getting quota rule")
+		return false, err
+	}
+	if rule.Type != ruleType {
+		d.SetId("")
+		return false, nil
+	}
+	return true, nil
+}
+
+func resourceQuotaRuleCustomizeDiff(diff *schema.ResourceDiff, v interface{}) error {
+	ruleType := diff.Get("type").(string)
+	if ruleType == "tree" {
+		if _, ok := diff.GetOk("user_or_group"); ok {
+			return fmt.Errorf("user_or_group is not supported for tree quota rules")
+		}
+	}
+	if diff.Get("soft_disk_limit").(int) > 0 && diff.Get("disk_limit").(int) > 0 && diff.Get("soft_disk_limit").(int) > diff.Get("disk_limit").(int) {
+		return fmt.Errorf("soft_disk_limit cannot be greater than disk_limit")
+	}
+	if diff.Get("soft_file_limit").(int) > 0 && diff.Get("file_limit").(int) > 0 && diff.Get("soft_file_limit").(int) > diff.Get("file_limit").(int) {
+		return fmt.Errorf("soft_file_limit cannot be greater than file_limit")
+	}
+	return nil
+}
+
+func resourceQuotaRuleImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ",")
+	if parts[0] != "Standard" && parts[0] != "Restricted" {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong option for deployment_mode: %s, options for deployment_mode are 'Standard' and 'Restricted'", parts[0])
+	}
+
+	if parts[0] == "Standard" && len(parts) != 8 {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'deployment_mode,client_id,working_environment_name,svm_name,volume_name,type,qtree_name,user_or_group'", d.Id())
+	}
+
+	if parts[0] == "Restricted" && len(parts) != 10 {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'deployment_mode,client_id,working_environment_name,svm_name,volume_name,type,qtree_name,user_or_group,tenant_id,connector_ip'", d.Id())
+	}
+
+	d.Set("deployment_mode", parts[0])
+	d.Set("client_id", parts[1])
+	d.Set("working_environment_name", parts[2])
+	d.Set("svm_name", parts[3])
+	d.Set("volume_name", parts[4])
+	d.Set("type", parts[5])
+	d.Set("qtree_name", parts[6])
+	d.Set("user_or_group", parts[7])
+	if parts[0] == "Restricted" {
+		d.Set("tenant_id", parts[8])
+		d.Set("connector_ip", parts[9])
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func buildQuotaRuleRequest(d *schema.ResourceData, workingEnv workingEnvironmentInfo) quotaRuleRequest {
+	request := quotaRuleRequest{}
+	request.WorkingEnvironmentID = workingEnv.PublicID
+	request.SvmName = getSvmName(d, workingEnv)
+	request.VolumeName = d.Get("volume_name").(string)
+	request.Type = d.Get("type").(string)
+	request.QtreeName = d.Get("qtree_name").(string)
+	request.UserOrGroup = d.Get("user_or_group").(string)
+	unit := d.Get("disk_limit_unit").(string)
+	request.DiskLimit = size{Size: float64(d.Get("disk_limit").(int)), Unit: unit}
+	request.SoftDiskLimit = size{Size: float64(d.Get("soft_disk_limit").(int)), Unit: unit}
+	request.FileLimit = d.Get("file_limit").(int)
+	request.SoftFileLimit = d.Get("soft_file_limit").(int)
+	return request
+}
+
+// convertQuotaLimit converts a disk limit returned by the API to the configured unit.
+func convertQuotaLimit(limit capacity, unit string) int {
+	size, err := convertSizeUnit(limit.Size, limit.Unit, unit)
+	if err != nil {
+		return int(limit.Size)
+	}
+	return int(size)
+}
@@ -11,11 +11,12 @@ import (
 
 func resourceCVOSnapMirror() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCVOSnapMirrorCreate,
-		Read:   resourceCVOSnapMirrorRead,
-		Delete: resourceCVOSnapMirrorDelete,
-		Exists: resourceCVOSnapMirrorExists,
-		Update: resourceCVOSnapMirrorUpdate,
+		Create:        resourceCVOSnapMirrorCreate,
+		Read:          resourceCVOSnapMirrorRead,
+		Delete:        resourceCVOSnapMirrorDelete,
+		Exists:        resourceCVOSnapMirrorExists,
+		Update:        resourceCVOSnapMirrorUpdate,
+		CustomizeDiff: resourceCVOSnapMirrorCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			State: resourceCVOSnapMirrorImport,
 		},
@@ -117,6 +118,56 @@ func resourceCVOSnapMirror() *schema.Resource {
 				Default:     false,
 				Description: "Set to true to delete the destination volume when the snapmirror relationship is destroyed",
 			},
+			"state": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"snapmirrored", "broken_off", "quiesced"}, false),
+			},
+			"max_lag": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Maximum replication lag in seconds, lag_exceeded is set when the relationship is further behind",
+			},
+			"healthy": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"mirror_state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"lag_time_seconds": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"lag_exceeded": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"last_transfer_size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"last_transfer_end": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"source_working_environment_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"destination_working_environment_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"reverse_resync": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Set to true to reverse the relationship of a broken off mirror, the source volume becomes the destination",
+			},
 		},
 	}
 }
@@ -235,11 +286,64 @@ func resourceCVOSnapMirrorRead(d *schema.ResourceData, meta interface{}) error {
 	snapMirror.ReplicationRequest.DestinationWorkingEnvironmentID = destWEInfo.PublicID
 	snapMirror.ReplicationVolume.SourceVolumeName = d.Get("source_volume_name").(string)
 	snapMirror.ReplicationVolume.DestinationVolumeName = d.Get("destination_volume_name").(string)
-	_, err = client.getSnapMirror(snapMirror, d.Id(), clientID, isSaas, connectorIP)
+	reversed := d.Get("reverse_resync").(bool)
+	relationship, err := client.getCurrentSnapMirrorStatus(snapMirror, reversed, clientID, isSaas, connectorIP)
 	if err != nil {
 		log.Print("Error getting SnapMirror")
 		return err
 	}
+	if relationship.Destination.VolumeName == "" {
+		log.Printf("SnapMirror relationship to %s not found", d.Id())
+		return nil
+	}
+
+	source, destination := relationship.Source, relationship.Destination
+	if reversed {
+		source, destination = destination, source
+	}
+	if source.SvmName != "" {
+		d.Set("source_svm_name", source.SvmName)
+	}
+	if destination.SvmName != "" {
+		d.Set("destination_svm_name", destination.SvmName)
+	}
+	d.Set("source_working_environment_type", sourceWEInfo.WorkingEnvironmentType)
+	d.Set("destination_working_environment_type", destWEInfo.WorkingEnvironmentType)
+	// an uninitialized relationship is still transferring its baseline, the configured state is kept until it completes
+	if state := getSnapMirrorState(relationship); state == "snapmirrored" || state == "broken_off" || state == "quiesced" {
+		d.Set("state", state)
+	} else {
+		log.Printf("SnapMirror relationship to %s is %s, keeping state %s", d.Id(), relationship.MirrorState, d.Get("state").(string))
+	}
+	d.Set("healthy", relationship.Healthy)
+	d.Set("mirror_state", relationship.MirrorState)
+	lastTransferSize := float64(relationship.LastTransferSize.Size)
+	if relationship.LastTransferSize.Unit != "" {
+		lastTransferSize, err = convertSizeUnit(lastTransferSize, relationship.LastTransferSize.Unit, "B")
+		if err != nil {
+			return fmt.Errorf("cannot read last transfer size: %s", err)
+		}
+	}
+	d.Set("last_transfer_size", int(lastTransferSize))
+	d.Set("last_transfer_end", getLastTransferEnd(relationship.LastTransferEnd))
+	lagTime := getLagTimeSeconds(relationship.LagTime)
+	d.Set("lag_time_seconds", lagTime)
+	// the lag check is exposed as an attribute and a log warning, CustomizeDiff also plans a change of the lag attributes
+	maxLag := d.Get("max_lag").(int)
+	d.Set("lag_exceeded", maxLag > 0 && lagTime > maxLag)
+	if maxLag > 0 && lagTime > maxLag {
+		log.Printf("[WARN] SnapMirror relationship to %s is %d seconds behind, exceeding max_lag of %d seconds", d.Id(), lagTime, maxLag)
+	}
+	if relationship.MirrorState != "" && !relationship.Healthy {
+		log.Printf("[WARN] SnapMirror relationship to %s is not healthy", d.Id())
+	}
+	d.Set("policy", relationship.Policy)
+	d.Set("schedule", relationship.Schedule)
+	maxTransferRate, err := getMaxTransferRate(relationship.MaxTransferRate)
+	if err != nil {
+		return err
+	}
+	d.Set("max_transfer_rate", maxTransferRate)
 
 	return nil
 }
@@ -274,7 +378,15 @@ func resourceCVOSnapMirrorDelete(d *schema.ResourceData, meta interface{}) error
 		snapMirror.ReplicationVolume.SourceSvmName = s.(string)
 	}
 
-	err = client.deleteSnapMirror(snapMirror, clientID, isSaas, connectorIP)
+	// a reversed relationship is deleted on the source volume, which is its current destination
+	relationshipToDelete := snapMirror
+	if d.Get("reverse_resync").(bool) {
+		relationshipToDelete.ReplicationRequest.DestinationWorkingEnvironmentID = sourceWEInfo.PublicID
+		relationshipToDelete.ReplicationVolume.DestinationSvmName = snapMirror.ReplicationVolume.SourceSvmName
+		relationshipToDelete.ReplicationVolume.DestinationVolumeName = snapMirror.ReplicationVolume.SourceVolumeName
+	}
+
+	err = client.deleteSnapMirror(relationshipToDelete, clientID, isSaas, connectorIP)
 	if err != nil {
 		log.Print("Error deleting SnapMirror")
 		return err
@@ -286,7 +398,11 @@ func resourceCVOSnapMirrorDelete(d *schema.ResourceData, meta interface{}) error
 
 		// Prepare volume request for deletion using the same logic as volume resource
 		volume := volumeRequest{}
-		volume.WorkingEnvironmentID = destWEInfo.PublicID
+		if strings.HasPrefix(destWEInfo.PublicID, "fs-") {
+			volume.FileSystemID = destWEInfo.PublicID
+		} else {
+			volume.WorkingEnvironmentID = destWEInfo.PublicID
+		}
 		volume.WorkingEnvironmentType = destWEInfo.WorkingEnvironmentType
 		volume.SvmName = snapMirror.ReplicationVolume.DestinationSvmName
 		volume.Name = snapMirror.ReplicationVolume.DestinationVolumeName
@@ -326,13 +442,13 @@ func resourceCVOSnapMirrorExists(d *schema.ResourceData, meta interface{}) (bool
 	snapMirror.ReplicationVolume.SourceVolumeName = d.Get("source_volume_name").(string)
 	snapMirror.ReplicationVolume.DestinationVolumeName = d.Get("destination_volume_name").(string)
 	snapMirror.ReplicationVolume.DestinationSvmName = d.Get("destination_svm_name").(string)
-	res, err := client.getSnapMirror(snapMirror, d.Id(), clientID, isSaas, connectorIP)
+	relationship, err := client.getCurrentSnapMirrorStatus(snapMirror, d.Get("reverse_resync").(bool), clientID, isSaas, connectorIP)
 	if err != nil {
 		log.Print("Error getting SnapMirror")
 		return false, err
 	}
 
-	if res != d.Id() {
+	if relationship.Destination.VolumeName == "" {
 		d.SetId("")
 		return false, nil
 	}
@@ -340,8 +456,65 @@ func resourceCVOSnapMirrorExists(d *schema.ResourceData, meta interface{}) (bool
 }
 
 func resourceCVOSnapMirrorUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Updating SnapMirror: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+	snapMirror := snapMirrorRequest{}
 
-	return nil
+	// Check deployment mode
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	sourceWEInfo, destWEInfo, err := client.getWorkingEnvironmentDetailForSnapMirror(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Cannot find working environment")
+		return err
+	}
+
+	// operations run on the current destination of the relationship, which is the source volume once reversed
+	oldReversed, newReversed := d.GetChange("reverse_resync")
+	destinationWEID, destinationSvm, destinationVolume := destWEInfo.PublicID, d.Get("destination_svm_name").(string), d.Get("destination_volume_name").(string)
+	if oldReversed.(bool) {
+		destinationWEID, destinationSvm, destinationVolume = sourceWEInfo.PublicID, d.Get("source_svm_name").(string), d.Get("source_volume_name").(string)
+	}
+
+	if d.HasChange("state") || d.HasChange("reverse_resync") {
+		oldState, newState := d.GetChange("state")
+		operations := getSnapMirrorOperations(oldState.(string), newState.(string), oldReversed.(bool) != newReversed.(bool))
+		for _, operation := range operations {
+			err = client.snapMirrorOperation(operation, destinationWEID, destinationSvm, destinationVolume, clientID, isSaas, connectorIP)
+			if err != nil {
+				log.Printf("Error running SnapMirror %s", operation)
+				return err
+			}
+		}
+		if oldReversed.(bool) != newReversed.(bool) {
+			destinationWEID, destinationSvm, destinationVolume = destWEInfo.PublicID, d.Get("destination_svm_name").(string), d.Get("destination_volume_name").(string)
+			if newReversed.(bool) {
+				destinationWEID, destinationSvm, destinationVolume = sourceWEInfo.PublicID, d.Get("source_svm_name").(string), d.Get("source_volume_name").(string)
+			}
+		}
+	}
+
+	if d.HasChange("policy") || d.HasChange("schedule") || d.HasChange("max_transfer_rate") {
+		snapMirror.ReplicationRequest.DestinationWorkingEnvironmentID = destinationWEID
+		snapMirror.ReplicationVolume.DestinationSvmName = destinationSvm
+		snapMirror.ReplicationVolume.DestinationVolumeName = destinationVolume
+
+		request := updateSnapMirrorRequest{}
+		request.PolicyName = d.Get("policy").(string)
+		request.ScheduleName = d.Get("schedule").(string)
+		request.MaxTransferRate = d.Get("max_transfer_rate").(int)
+		err = client.updateSnapMirror(snapMirror, request, clientID, isSaas, connectorIP)
+		if err != nil {
+			log.Print("Error updating SnapMirror")
+			return err
+		}
+	}
+
+	return resourceCVOSnapMirrorRead(d, meta)
 }
 
 func resourceCVOSnapMirrorImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -351,12 +524,12 @@ func resourceCVOSnapMirrorImport(d *schema.ResourceData, meta interface{}) ([]*s
 	importID := d.Id()
 
 	// Parse the import ID - expect different formats based on deployment mode
-	// Standard mode: deployment_mode,client_id,destination_volume_name
+	// Standard mode: deployment_mode,client_id,destination_volume_name[,tenant_id]
 	// Restricted mode: deployment_mode,client_id,destination_volume_name,tenant_id,connector_ip
 	parts := strings.Split(importID, ",")
 
 	if len(parts) < 3 || (parts[0] != "Standard" && parts[0] != "Restricted") {
-		return nil, fmt.Errorf("invalid import ID format. Expected: deployment_mode,client_id,destination_volume_name or deployment_mode,client_id,destination_volume_name,tenant_id,connector_ip for Restricted mode, got: %s", importID)
+		return nil, fmt.Errorf("invalid import ID format. Expected: deployment_mode,client_id,destination_volume_name[,tenant_id] or deployment_mode,client_id,destination_volume_name,tenant_id,connector_ip for Restricted mode, got: %s", importID)
 	}
 
 	deploymentMode := parts[0]
@@ -375,8 +548,12 @@ func resourceCVOSnapMirrorImport(d *schema.ResourceData, meta interface{}) ([]*s
 		d.Set("tenant_id", parts[3])
 		d.Set("connector_ip", parts[4])
 	} else if deploymentMode == "Standard" {
-		if len(parts) != 3 {
-			return nil, fmt.Errorf("invalid import ID format for Standard mode. Expected: deployment_mode,client_id,destination_volume_name, got: %s", importID)
+		if len(parts) != 3 && len(parts) != 4 {
+			return nil, fmt.Errorf("invalid import ID format for Standard mode. Expected: deployment_mode,client_id,destination_volume_name[,tenant_id], got: %s", importID)
+		}
+		// tenant_id is required to look up FSx working environments
+		if len(parts) == 4 {
+			d.Set("tenant_id", parts[3])
 		}
 	}
 
@@ -403,7 +580,11 @@ func resourceCVOSnapMirrorImport(d *schema.ResourceData, meta interface{}) ([]*s
 	d.Set("destination_svm_name", relationship.Destination.SvmName)
 	d.Set("policy", relationship.Policy)
 	d.Set("schedule", relationship.Schedule)
-	d.Set("max_transfer_rate", relationship.MaxTransferRate.Size)
+	maxTransferRate, err := getMaxTransferRate(relationship.MaxTransferRate)
+	if err != nil {
+		return nil, err
+	}
+	d.Set("max_transfer_rate", maxTransferRate)
 
 	if _, ok := d.GetOk("delete_destination_volume"); !ok {
 		d.Set("delete_destination_volume", false)
@@ -426,13 +607,26 @@ func resourceCVOSnapMirrorImport(d *schema.ResourceData, meta interface{}) ([]*s
 	// This is needed because the status API doesn't include all volume details
 	if relationship.Destination.WorkingEnvironmentID != "" {
 		volumeRequest := volumeRequest{
-			WorkingEnvironmentID: relationship.Destination.WorkingEnvironmentID,
-			Name:                 relationship.Destination.VolumeName,
+			Name: relationship.Destination.VolumeName,
 		}
-
-		volumes, err := client.getVolume(volumeRequest, clientID, isSaas, connectorIP)
-		if err == nil && len(volumes) > 0 {
-			volume := volumes[0]
+		var volumes []volumeResponse
+		if strings.HasPrefix(relationship.Destination.WorkingEnvironmentID, "fs-") {
+			volumeRequest.FileSystemID = relationship.Destination.WorkingEnvironmentID
+			volumes, err = client.getVolume(volumeRequest, clientID, isSaas, connectorIP)
+		} else if relationship.Destination.WorkingEnvironmentType == "ON_PREM" {
+			volumeRequest.WorkingEnvironmentID = relationship.Destination.WorkingEnvironmentID
+			volumes, err = client.getVolumeForOnPrem(volumeRequest, clientID, isSaas, connectorIP)
+		} else {
+			volumeRequest.WorkingEnvironmentID = relationship.Destination.WorkingEnvironmentID
+			volumes, err = client.getVolume(volumeRequest, clientID, isSaas, connectorIP)
+		}
+		if err != nil {
+			log.Printf("Error reading destination volume %s during import: %v", relationship.Destination.VolumeName, err)
+		}
+		for _, volume := range volumes {
+			if volume.Name != relationship.Destination.VolumeName {
+				continue
+			}
 			if volume.AggregateName != "" {
 				d.Set("destination_aggregate_name", volume.AggregateName)
 			}
@@ -447,3 +641,54 @@ func resourceCVOSnapMirrorImport(d *schema.ResourceData, meta interface{}) ([]*s
 
 	return []*schema.ResourceData{d}, nil
 }
+
+func resourceCVOSnapMirrorCustomizeDiff(diff *schema.ResourceDiff, v interface{}) error {
+	if diff.Id() == "" {
+		if state, ok := diff.GetOk("state"); ok && state.(string) != "snapmirrored" {
+			return fmt.Errorf("state must be snapmirrored when the relationship is created")
+		}
+		if diff.Get("reverse_resync").(bool) {
+			return fmt.Errorf("reverse_resync can only be set on an existing relationship")
+		}
+		return nil
+	}
+	if diff.HasChange("reverse_resync") {
+		oldState, newState := diff.GetChange("state")
+		if oldState.(string) != "broken_off" || newState.(string) != "snapmirrored" {
+			return fmt.Errorf("reverse_resync can only be changed when a broken_off relationship is set to snapmirrored")
+		}
+	}
+	if diff.HasChange("state") {
+		oldState, newState := diff.GetChange("state")
+		if oldState.(string) == "broken_off" && newState.(string) == "quiesced" {
+			return fmt.Errorf("a broken_off relationship can not be quiesced, set state to snapmirrored to resync it first")
+		}
+	}
+	return nil
+}
+
+// getSnapMirrorOperations returns the operations moving the relationship from one state to another
+func getSnapMirrorOperations(oldState string, newState string, reverse bool) []string {
+	if reverse {
+		return []string{"reverse-resync"}
+	}
+	switch {
+	case oldState == newState || newState == "":
+		return nil
+	case newState == "snapmirrored" && (oldState == "" || oldState == "uninitialized"):
+		// the baseline transfer is still running, a resync would restart it
+		return nil
+	case newState == "quiesced":
+		return []string{"quiesce"}
+	case newState == "broken_off":
+		if oldState == "snapmirrored" {
+			// transfers are quiesced before the mirror is broken
+			return []string{"quiesce", "break"}
+		}
+		return []string{"break"}
+	case oldState == "quiesced":
+		return []string{"resume"}
+	default:
+		return []string{"resync"}
+	}
+}
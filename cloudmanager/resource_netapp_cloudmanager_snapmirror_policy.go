@@ -0,0 +1,290 @@
+package cloudmanager
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceSnapMirrorPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSnapMirrorPolicyCreate,
+		Read:   resourceSnapMirrorPolicyRead,
+		Delete: resourceSnapMirrorPolicyDelete,
+		Exists: resourceSnapMirrorPolicyExists,
+		Update: resourceSnapMirrorPolicyUpdate,
+		Importer: &schema.ResourceImporter{
+			State: resourceSnapMirrorPolicyImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"policy_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"async", "sync", "vault"}, false),
+			},
+			"comment": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"rule": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"snapmirror_label": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"keep": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(1, 1023),
+						},
+						"schedule": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"connector_ip": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"deployment_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"Standard", "Restricted"}, false),
+				Default:      "Standard",
+			},
+		},
+	}
+}
+
+func resourceSnapMirrorPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Creating snapmirror policy: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+
+	request := buildSnapMirrorPolicyRequest(d, workingEnv.PublicID)
+	err = client.createSnapMirrorPolicy(request, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error creating snapmirror policy")
+		return err
+	}
+	d.SetId(request.Name)
+
+	return resourceSnapMirrorPolicyRead(d, meta)
+}
+
+func resourceSnapMirrorPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Reading snapmirror policy: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+	name := d.Get("name").(string)
+
+	policy, err := client.getSnapMirrorPolicy(workingEnv.PublicID, name, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error reading snapmirror policy")
+		return err
+	}
+	if policy.Name != name {
+		return fmt.Errorf("expected snapmirror policy name %v, Response could not find", name)
+	}
+
+	if strings.Contains(d.Id(), ",") {
+		d.SetId(policy.Name)
+		d.Set("working_environment_name", workingEnv.Name)
+	}
+	d.Set("policy_type", policy.PolicyType)
+	d.Set("comment", policy.Comment)
+	if err := d.Set("rule", flattenSnapMirrorPolicyRules(policy.Rules)); err != nil {
+		return fmt.Errorf("error reading snapmirror policy rule: %s", err)
+	}
+
+	return nil
+}
+
+func resourceSnapMirrorPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Updating snapmirror policy: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+
+	if d.HasChange("rule") || d.HasChange("comment") {
+		request := buildSnapMirrorPolicyRequest(d, workingEnv.PublicID)
+		err = client.updateSnapMirrorPolicy(request, clientID, isSaas, connectorIP)
+		if err != nil {
+			log.Print("Error updating snapmirror policy")
+			return err
+		}
+	}
+
+	return resourceSnapMirrorPolicyRead(d, meta)
+}
+
+func resourceSnapMirrorPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Deleting snapmirror policy: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+
+	err = client.deleteSnapMirrorPolicy(workingEnv.PublicID, d.Get("name").(string), clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error deleting snapmirror policy")
+		return err
+	}
+	return nil
+}
+
+func resourceSnapMirrorPolicyExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	log.Printf("Checking existence of snapmirror policy: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return false, err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return false, fmt.Errorf("cannot find working environment")
+	}
+
+	name := d.Get("name").(string)
+	policy, err := client.getSnapMirrorPolicy(workingEnv.PublicID, name, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error getting snapmirror policy")
+		return false, err
+	}
+	if policy.Name != name {
+		d.SetId("")
+		return false, nil
+	}
+	return true, nil
+}
+
+func resourceSnapMirrorPolicyImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ",")
+	if parts[0] != "Standard" && parts[0] != "Restricted" {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong option for deployment_mode: %s, options for deployment_mode are 'Standard' and 'Restricted'", parts[0])
+	}
+
+	if parts[0] == "Standard" && len(parts) != 4 {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'deployment_mode,client_id,working_environment_name,name'", d.Id())
+	}
+
+	if parts[0] == "Restricted" && len(parts) != 6 {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'deployment_mode,client_id,working_environment_name,name,tenant_id,connector_ip'", d.Id())
+	}
+
+	d.Set("deployment_mode", parts[0])
+	d.Set("client_id", parts[1])
+	d.Set("working_environment_name", parts[2])
+	d.Set("name", parts[3])
+	if parts[0] == "Restricted" {
+		d.Set("tenant_id", parts[4])
+		d.Set("connector_ip", parts[5])
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func buildSnapMirrorPolicyRequest(d *schema.ResourceData, workingEnvironmentID string) snapMirrorPolicyRequest {
+	request := snapMirrorPolicyRequest{
+		Name:                 d.Get("name").(string),
+		PolicyType:           d.Get("policy_type").(string),
+		Comment:              d.Get("comment").(string),
+		Rules:                []snapMirrorPolicyRule{},
+		WorkingEnvironmentID: workingEnvironmentID,
+	}
+	for _, v := range d.Get("rule").([]interface{}) {
+		rule := v.(map[string]interface{})
+		request.Rules = append(request.Rules, snapMirrorPolicyRule{
+			SnapmirrorLabel: rule["snapmirror_label"].(string),
+			Keep:            rule["keep"].(int),
+			Schedule:        rule["schedule"].(string),
+		})
+	}
+	return request
+}
+
+func flattenSnapMirrorPolicyRules(rules []snapMirrorPolicyRule) []interface{} {
+	result := make([]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		entry := make(map[string]interface{})
+		entry["snapmirror_label"] = rule.SnapmirrorLabel
+		entry["keep"] = rule.Keep
+		entry["schedule"] = rule.Schedule
+		result = append(result, entry)
+	}
+	return result
+}
@@ -0,0 +1,261 @@
+package cloudmanager
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccSnapMirror_onPremToCVO(t *testing.T) {
+	clientID := "vAWgtc8ZcLRshb08kybl2Uhh9W0o5ElE"
+	var relationship snapMirrorStatusResponse
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSnapMirrorDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSnapMirrorConfigCreate(clientID, "OnPremWorkingEnvironment-8o1lvkbz", "VsaWorkingEnvironment-kdnltvwn", "acc_onprem_vol", ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSnapMirrorExists("netapp-cloudmanager_snapmirror.acc-snapmirror", &relationship),
+					resource.TestCheckResourceAttr("netapp-cloudmanager_snapmirror.acc-snapmirror", "source_working_environment_type", "ON_PREM"),
+					resource.TestCheckResourceAttr("netapp-cloudmanager_snapmirror.acc-snapmirror", "state", "snapmirrored"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSnapMirror_cvoToFSX(t *testing.T) {
+	clientID := "vAWgtc8ZcLRshb08kybl2Uhh9W0o5ElE"
+	var relationship snapMirrorStatusResponse
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSnapMirrorDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSnapMirrorConfigCreate(clientID, "VsaWorkingEnvironment-kdnltvwn", "fs-wji22bngfx__3_1_183_4", "acc_cvo_vol", "account-j3aZttuL"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSnapMirrorExists("netapp-cloudmanager_snapmirror.acc-snapmirror", &relationship),
+					resource.TestCheckResourceAttr("netapp-cloudmanager_snapmirror.acc-snapmirror", "destination_working_environment_type", "FSX"),
+					resource.TestCheckResourceAttr("netapp-cloudmanager_snapmirror.acc-snapmirror", "state", "snapmirrored"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSnapMirror_fsxToCVO(t *testing.T) {
+	clientID := "vAWgtc8ZcLRshb08kybl2Uhh9W0o5ElE"
+	var relationship snapMirrorStatusResponse
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSnapMirrorDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSnapMirrorConfigCreate(clientID, "fs-wji22bngfx__3_1_183_4", "VsaWorkingEnvironment-kdnltvwn", "acc_test_vol_2", "account-j3aZttuL"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSnapMirrorExists("netapp-cloudmanager_snapmirror.acc-snapmirror", &relationship),
+					resource.TestCheckResourceAttr("netapp-cloudmanager_snapmirror.acc-snapmirror", "source_working_environment_type", "FSX"),
+					resource.TestCheckResourceAttr("netapp-cloudmanager_snapmirror.acc-snapmirror", "state", "snapmirrored"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSnapMirrorDestroy(state *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "netapp-cloudmanager_snapmirror" {
+			continue
+		}
+		var snapMirror snapMirrorRequest
+		snapMirror.ReplicationRequest.SourceWorkingEnvironmentID = rs.Primary.Attributes["source_working_environment_id"]
+		snapMirror.ReplicationRequest.DestinationWorkingEnvironmentID = rs.Primary.Attributes["destination_working_environment_id"]
+		response, err := client.getSnapMirrorStatus(snapMirror, rs.Primary.Attributes["destination_volume_name"], rs.Primary.Attributes["client_id"], true, "")
+		if err == nil {
+			if response.Destination.VolumeName != "" {
+				return fmt.Errorf("snapmirror relationship to (%s) still exists", response.Destination.VolumeName)
+			}
+		}
+	}
+	return nil
+}
+
+func testAccCheckSnapMirrorExists(name string, relationship *snapMirrorStatusResponse) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No snapmirror ID is set")
+		}
+		var snapMirror snapMirrorRequest
+		snapMirror.ReplicationRequest.SourceWorkingEnvironmentID = rs.Primary.Attributes["source_working_environment_id"]
+		snapMirror.ReplicationRequest.DestinationWorkingEnvironmentID = rs.Primary.Attributes["destination_working_environment_id"]
+		response, err := client.getSnapMirrorStatus(snapMirror, rs.Primary.Attributes["destination_volume_name"], rs.Primary.Attributes["client_id"], true, "")
+		if err != nil {
+			return err
+		}
+		if response.Destination.VolumeName != rs.Primary.ID {
+			return fmt.Errorf("Resource ID and snapmirror destination volume do not match")
+		}
+
+		*relationship = response
+		return nil
+	}
+}
+
+func testAccSnapMirrorConfigCreate(clientID string, sourceWorkingEnvironmentID string, destinationWorkingEnvironmentID string, sourceVolumeName string, tenantID string) string {
+	tenant := ""
+	if tenantID != "" {
+		tenant = fmt.Sprintf("tenant_id = \"%s\"", tenantID)
+	}
+	return fmt.Sprintf(`
+	resource "netapp-cloudmanager_snapmirror" "acc-snapmirror" {
+		provider = netapp-cloudmanager
+		source_working_environment_id = "%s"
+		destination_working_environment_id = "%s"
+		source_volume_name = "%s"
+		destination_volume_name = "%s_copy"
+		policy = "MirrorAllSnapshots"
+		schedule = "1hour"
+		max_transfer_rate = "102400"
+		delete_destination_volume = true
+		client_id = "%s"
+		%s
+	}`, sourceWorkingEnvironmentID, destinationWorkingEnvironmentID, sourceVolumeName, sourceVolumeName, clientID, tenant)
+}
+
+func TestGetSnapMirrorOperations(t *testing.T) {
+	cases := []struct {
+		oldState string
+		newState string
+		reverse  bool
+		expected []string
+	}{
+		{"snapmirrored", "snapmirrored", false, nil},
+		{"snapmirrored", "", false, nil},
+		{"snapmirrored", "quiesced", false, []string{"quiesce"}},
+		{"snapmirrored", "broken_off", false, []string{"quiesce", "break"}},
+		{"quiesced", "broken_off", false, []string{"break"}},
+		{"quiesced", "snapmirrored", false, []string{"resume"}},
+		{"broken_off", "snapmirrored", false, []string{"resync"}},
+		{"broken_off", "snapmirrored", true, []string{"reverse-resync"}},
+		{"uninitialized", "snapmirrored", false, nil},
+		{"", "snapmirrored", false, nil},
+	}
+	for _, c := range cases {
+		if operations := getSnapMirrorOperations(c.oldState, c.newState, c.reverse); !reflect.DeepEqual(operations, c.expected) {
+			t.Errorf("%s to %s (reverse %v): expected operations %v, got %v", c.oldState, c.newState, c.reverse, c.expected, operations)
+		}
+	}
+}
+
+func TestGetSnapMirrorState(t *testing.T) {
+	cases := []struct {
+		relationship snapMirrorStatusResponse
+		expected     string
+	}{
+		{snapMirrorStatusResponse{MirrorState: "snapmirrored", RelationshipStatus: "idle"}, "snapmirrored"},
+		{snapMirrorStatusResponse{MirrorState: "snapmirrored", RelationshipStatus: "quiesced"}, "quiesced"},
+		{snapMirrorStatusResponse{MirrorState: "broken-off", RelationshipStatus: "idle"}, "broken_off"},
+		{snapMirrorStatusResponse{MirrorState: "in_sync", RelationshipStatus: "idle"}, "snapmirrored"},
+		{snapMirrorStatusResponse{MirrorState: "uninitialized", RelationshipStatus: "transferring"}, "uninitialized"},
+	}
+	for _, c := range cases {
+		if state := getSnapMirrorState(c.relationship); state != c.expected {
+			t.Errorf("mirror state %s, relationship status %s: expected %s, got %s", c.relationship.MirrorState, c.relationship.RelationshipStatus, c.expected, state)
+		}
+	}
+}
+
+func TestResourceCVOSnapMirrorCustomizeDiff(t *testing.T) {
+	cases := []struct {
+		name     string
+		id       string
+		oldState string
+		config   map[string]interface{}
+		hasError bool
+	}{
+		{"create snapmirrored", "", "", map[string]interface{}{"state": "snapmirrored"}, false},
+		{"create quiesced", "", "", map[string]interface{}{"state": "quiesced"}, true},
+		{"create reverse resync", "", "", map[string]interface{}{"reverse_resync": true}, true},
+		{"break", "vol1_copy", "snapmirrored", map[string]interface{}{"state": "broken_off"}, false},
+		{"quiesce broken off", "vol1_copy", "broken_off", map[string]interface{}{"state": "quiesced"}, true},
+		{"reverse resync broken off", "vol1_copy", "broken_off", map[string]interface{}{"state": "snapmirrored", "reverse_resync": true}, false},
+		{"reverse resync snapmirrored", "vol1_copy", "snapmirrored", map[string]interface{}{"state": "snapmirrored", "reverse_resync": true}, true},
+	}
+	for _, c := range cases {
+		config := map[string]interface{}{
+			"source_volume_name":      "vol1",
+			"destination_volume_name": "vol1_copy",
+			"client_id":               "client",
+		}
+		for k, v := range c.config {
+			config[k] = v
+		}
+		var state *terraform.InstanceState
+		if c.id != "" {
+			state = &terraform.InstanceState{ID: c.id, Attributes: map[string]string{
+				"source_volume_name":      "vol1",
+				"destination_volume_name": "vol1_copy",
+				"client_id":               "client",
+				"state":                   c.oldState,
+				"reverse_resync":          "false",
+			}}
+		}
+		_, err := resourceCVOSnapMirror().Diff(state, terraform.NewResourceConfigRaw(config), nil)
+		if (err != nil) != c.hasError {
+			t.Errorf("%s: expected error %v, got %v", c.name, c.hasError, err)
+		}
+	}
+}
+
+func TestResourceCVOSnapMirrorCustomizeDiffMaxLag(t *testing.T) {
+	config := map[string]interface{}{
+		"source_volume_name":      "vol1",
+		"destination_volume_name": "vol1_copy",
+		"client_id":               "client",
+		"max_lag":                 3600,
+	}
+	// a relationship behind max_lag is only reported, it must not plan a change
+	for _, lag := range []string{"600", "7200"} {
+		state := &terraform.InstanceState{ID: "vol1_copy", Attributes: map[string]string{
+			"source_volume_name":        "vol1",
+			"destination_volume_name":   "vol1_copy",
+			"client_id":                 "client",
+			"max_lag":                   "3600",
+			"lag_time_seconds":          lag,
+			"lag_exceeded":              "false",
+			"policy":                    "MirrorAllSnapshots",
+			"schedule":                  "1hour",
+			"max_transfer_rate":         "100000",
+			"deployment_mode":           "Standard",
+			"delete_destination_volume": "false",
+			"reverse_resync":            "false",
+		}}
+		diff, err := resourceCVOSnapMirror().Diff(state, terraform.NewResourceConfigRaw(config), nil)
+		if err != nil {
+			t.Fatalf("lag %s: unexpected error %s", lag, err)
+		}
+		if diff != nil && !diff.Empty() {
+			t.Errorf("lag %s: expected an empty plan, got %#v", lag, diff.Attributes)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package cloudmanager
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceSnapshotPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSnapshotPolicyCreate,
+		Read:   resourceSnapshotPolicyRead,
+		Delete: resourceSnapshotPolicyDelete,
+		Exists: resourceSnapshotPolicyExists,
+		Update: resourceSnapshotPolicyUpdate,
+		Importer: &schema.ResourceImporter{
+			State: resourceSnapshotPolicyImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"schedule": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"schedule_type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"5min", "8hour", "hourly", "daily", "weekly", "monthly"}, true),
+						},
+						"retention": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(1, 1023),
+						},
+					},
+				},
+			},
+			"connector_ip": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"deployment_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"Standard", "Restricted"}, false),
+				Default:      "Standard",
+			},
+		},
+	}
+}
+
+func resourceSnapshotPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Creating snapshot policy: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+
+	request := buildSnapshotPolicyRequest(workingEnv.PublicID, d.Get("name").(string), d.Get("schedule").([]interface{}))
+	err = client.createSnapshotPolicyWithSchedules(request, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error creating snapshot policy")
+		return err
+	}
+	d.SetId(request.SnapshotPolicyName)
+
+	return resourceSnapshotPolicyRead(d, meta)
+}
+
+func resourceSnapshotPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Reading snapshot policy: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+	name := d.Get("name").(string)
+
+	policy, err := client.getSnapshotPolicy(workingEnv.PublicID, name, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error reading snapshot policy")
+		return err
+	}
+	if policy.Name != name {
+		return fmt.Errorf("expected snapshot policy name %v, Response could not find", name)
+	}
+
+	if strings.Contains(d.Id(), ",") {
+		d.SetId(policy.Name)
+		d.Set("working_environment_name", workingEnv.Name)
+	}
+	if err := d.Set("schedule", flattenSnapshotPolicySchedules(policy.Schedules)); err != nil {
+		return fmt.Errorf("error reading snapshot policy schedule: %s", err)
+	}
+
+	return nil
+}
+
+func resourceSnapshotPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Updating snapshot policy: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+
+	if d.HasChange("schedule") {
+		request := buildSnapshotPolicyRequest(workingEnv.PublicID, d.Get("name").(string), d.Get("schedule").([]interface{}))
+		err = client.updateSnapshotPolicy(request, clientID, isSaas, connectorIP)
+		if err != nil {
+			log.Print("Error updating snapshot policy")
+			return err
+		}
+	}
+
+	return resourceSnapshotPolicyRead(d, meta)
+}
+
+func resourceSnapshotPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Deleting snapshot policy: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+
+	err = client.deleteSnapshotPolicy(workingEnv.PublicID, d.Get("name").(string), clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error deleting snapshot policy")
+		return err
+	}
+	return nil
+}
+
+func resourceSnapshotPolicyExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	log.Printf("Checking existence of snapshot policy: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return false, err
+	}
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return false, fmt.Errorf("cannot find working environment")
+	}
+
+	name := d.Get("name").(string)
+	policy, err := client.getSnapshotPolicy(workingEnv.PublicID, name, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error getting snapshot policy")
+		return false, err
+	}
+	if policy.Name != name {
+		d.SetId("")
+		return false, nil
+	}
+	return true, nil
+}
+
+func resourceSnapshotPolicyImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ",")
+	if parts[0] != "Standard" && parts[0] != "Restricted" {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong option for deployment_mode: %s, options for deployment_mode are 'Standard' and 'Restricted'", parts[0])
+	}
+
+	if parts[0] == "Standard" && len(parts) != 4 {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'deployment_mode,client_id,working_environment_name,name'", d.Id())
+	}
+
+	if parts[0] == "Restricted" && len(parts) != 6 {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'deployment_mode,client_id,working_environment_name,name,tenant_id,connector_ip'", d.Id())
+	}
+
+	d.Set("deployment_mode", parts[0])
+	d.Set("client_id", parts[1])
+	d.Set("working_environment_name", parts[2])
+	d.Set("name", parts[3])
+	if parts[0] == "Restricted" {
+		d.Set("tenant_id", parts[4])
+		d.Set("connector_ip", parts[5])
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func flattenSnapshotPolicySchedules(schedules []policySchedule) []interface{} {
+	result := make([]interface{}, 0, len(schedules))
+	for _, schedule := range schedules {
+		entry := make(map[string]interface{})
+		entry["schedule_type"] = schedule.Frequency
+		entry["retention"] = schedule.Retention
+		result = append(result, entry)
+	}
+	return result
+}
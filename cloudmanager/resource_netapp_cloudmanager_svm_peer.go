@@ -0,0 +1,267 @@
+package cloudmanager
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceSvmPeer() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSvmPeerCreate,
+		Read:   resourceSvmPeerRead,
+		Delete: resourceSvmPeerDelete,
+		Exists: resourceSvmPeerExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceSvmPeerImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"source_working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"source_working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"destination_working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"destination_working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"source_svm_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"destination_svm_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"connector_ip": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"deployment_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"Standard", "Restricted"}, false),
+				Default:      "Standard",
+			},
+		},
+	}
+}
+
+func resourceSvmPeerCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Creating svm peer: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	sourceWEInfo, destWEInfo, err := client.getWorkingEnvironmentDetailForSnapMirror(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Cannot find working environment")
+		return err
+	}
+
+	request := svmPeerRequest{}
+	request.WorkingEnvironmentID = sourceWEInfo.PublicID
+	request.SvmName = getSvmPeerSvmName(d, "source_svm_name", sourceWEInfo)
+	request.PeerWorkingEnvironmentID = destWEInfo.PublicID
+	request.PeerSvmName = getSvmPeerSvmName(d, "destination_svm_name", destWEInfo)
+	request.Applications = []string{"snapmirror"}
+
+	peer, err := client.getSvmPeer(request.WorkingEnvironmentID, request.SvmName, request.PeerWorkingEnvironmentID, request.PeerSvmName, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error reading svm peer")
+		return err
+	}
+	if peer.SvmName != "" {
+		return fmt.Errorf("svm %s is already peered with svm %s, import it into this resource to manage the svm peer", request.SvmName, request.PeerSvmName)
+	}
+	if request.WorkingEnvironmentID != request.PeerWorkingEnvironmentID {
+		clusterPeer, err := client.getClusterPeer(request.WorkingEnvironmentID, request.PeerWorkingEnvironmentID, clientID, isSaas, connectorIP)
+		if err != nil {
+			log.Print("Error reading cluster peer")
+			return err
+		}
+		if clusterPeer.PeerClusterName == "" {
+			return fmt.Errorf("working environment %s is not peered with %s, create a netapp-cloudmanager_cluster_peer before the svm peer", request.WorkingEnvironmentID, request.PeerWorkingEnvironmentID)
+		}
+	}
+	err = client.createSvmPeer(request, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error creating svm peer")
+		return err
+	}
+	d.SetId(request.WorkingEnvironmentID + ":" + request.SvmName + ":" + request.PeerWorkingEnvironmentID + ":" + request.PeerSvmName)
+	d.Set("source_svm_name", request.SvmName)
+	d.Set("destination_svm_name", request.PeerSvmName)
+
+	return resourceSvmPeerRead(d, meta)
+}
+
+func resourceSvmPeerRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Reading svm peer: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	sourceWEInfo, destWEInfo, err := client.getWorkingEnvironmentDetailForSnapMirror(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Cannot find working environment")
+		return err
+	}
+	svmName := getSvmPeerSvmName(d, "source_svm_name", sourceWEInfo)
+	peerSvmName := getSvmPeerSvmName(d, "destination_svm_name", destWEInfo)
+
+	peer, err := client.getSvmPeer(sourceWEInfo.PublicID, svmName, destWEInfo.PublicID, peerSvmName, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error reading svm peer")
+		return err
+	}
+	if peer.SvmName == "" {
+		return fmt.Errorf("expected svm peer of svm %v to svm %v, Response could not find", svmName, peerSvmName)
+	}
+
+	if strings.Contains(d.Id(), ",") {
+		d.SetId(sourceWEInfo.PublicID + ":" + svmName + ":" + destWEInfo.PublicID + ":" + peerSvmName)
+	}
+	d.Set("source_svm_name", svmName)
+	d.Set("destination_svm_name", peerSvmName)
+	d.Set("state", peer.State)
+	if peer.State != "" && peer.State != "peered" {
+		log.Printf("[WARN] svm peer of svm %s to svm %s is %s", svmName, peerSvmName, peer.State)
+	}
+
+	return nil
+}
+
+func resourceSvmPeerDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Deleting svm peer: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return err
+	}
+
+	sourceWEInfo, destWEInfo, err := client.getWorkingEnvironmentDetailForSnapMirror(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Cannot find working environment")
+		return err
+	}
+
+	err = client.deleteSvmPeer(sourceWEInfo.PublicID, getSvmPeerSvmName(d, "source_svm_name", sourceWEInfo), getSvmPeerSvmName(d, "destination_svm_name", destWEInfo), clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error deleting svm peer")
+		return err
+	}
+	return nil
+}
+
+func resourceSvmPeerExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	log.Printf("Checking existence of svm peer: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	isSaas, connectorIP, err := client.checkDeploymentMode(d, clientID)
+	if err != nil {
+		return false, err
+	}
+
+	sourceWEInfo, destWEInfo, err := client.getWorkingEnvironmentDetailForSnapMirror(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Cannot find working environment")
+		return false, err
+	}
+
+	peer, err := client.getSvmPeer(sourceWEInfo.PublicID, getSvmPeerSvmName(d, "source_svm_name", sourceWEInfo), destWEInfo.PublicID, getSvmPeerSvmName(d, "destination_svm_name", destWEInfo), clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error getting svm peer")
+		return false, err
+	}
+	if peer.SvmName == "" {
+		d.SetId("")
+		return false, nil
+	}
+	return true, nil
+}
+
+func resourceSvmPeerImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ",")
+	if parts[0] != "Standard" && parts[0] != "Restricted" {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong option for deployment_mode: %s, options for deployment_mode are 'Standard' and 'Restricted'", parts[0])
+	}
+
+	if parts[0] == "Standard" && len(parts) != 6 {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'deployment_mode,client_id,source_working_environment_name,source_svm_name,destination_working_environment_name,destination_svm_name'", d.Id())
+	}
+
+	if parts[0] == "Restricted" && len(parts) != 8 {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'deployment_mode,client_id,source_working_environment_name,source_svm_name,destination_working_environment_name,destination_svm_name,tenant_id,connector_ip'", d.Id())
+	}
+
+	d.Set("deployment_mode", parts[0])
+	d.Set("client_id", parts[1])
+	d.Set("source_working_environment_name", parts[2])
+	d.Set("source_svm_name", parts[3])
+	d.Set("destination_working_environment_name", parts[4])
+	d.Set("destination_svm_name", parts[5])
+	if parts[0] == "Restricted" {
+		d.Set("tenant_id", parts[6])
+		d.Set("connector_ip", parts[7])
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// getSvmPeerSvmName returns the SVM name set in the key, or the default SVM of the working environment
+func getSvmPeerSvmName(d *schema.ResourceData, key string, workingEnv workingEnvironmentInfo) string {
+	if v, ok := d.GetOk(key); ok {
+		return v.(string)
+	}
+	if workingEnv.SvmName != "" {
+		return workingEnv.SvmName
+	}
+	return "svm_" + workingEnv.Name
+}
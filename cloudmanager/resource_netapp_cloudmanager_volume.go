@@ -3,6 +3,9 @@ package cloudmanager
 import (
 	"fmt"
 	"log"
+	"math"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -43,6 +46,54 @@ func resourceCVOVolume() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"aggregate_created": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"volume_style": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "flexvol",
+				ValidateFunc: validation.StringInSlice([]string{"flexvol", "flexgroup"}, false),
+			},
+			"aggregates": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"constituent_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"constituents": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"aggregate_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"size": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"unit": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 			"size": {
 				Type:     schema.TypeFloat,
 				Required: true,
@@ -59,14 +110,64 @@ func resourceCVOVolume() *schema.Resource {
 			"enable_thin_provisioning": {
 				Type:     schema.TypeBool,
 				Optional: true,
+				Computed: true,
 			},
 			"enable_compression": {
 				Type:     schema.TypeBool,
 				Optional: true,
+				Computed: true,
 			},
 			"enable_deduplication": {
 				Type:     schema.TypeBool,
 				Optional: true,
+				Computed: true,
+			},
+			"encryption": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"encryption_key_manager": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"onboard", "external"}, false),
+			},
+			"snaplock_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"compliance", "enterprise"}, false),
+			},
+			"snaplock_default_retention": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateSnaplockPeriod("infinite", "min", "max"),
+			},
+			"snaplock_minimum_retention": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateSnaplockPeriod("infinite"),
+			},
+			"snaplock_maximum_retention": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateSnaplockPeriod("infinite"),
+			},
+			"snaplock_autocommit_period": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateSnaplockPeriod("none"),
+			},
+			"anti_ransomware_state": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"disabled", "dry_run", "enabled"}, false),
 			},
 			"client_id": {
 				Type:     schema.TypeString,
@@ -129,9 +230,15 @@ func resourceCVOVolume() *schema.Resource {
 			"volume_protocol": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				ValidateFunc: validation.StringInSlice([]string{"nfs", "cifs", "iscsi"}, false),
+				ValidateFunc: validation.StringInSlice([]string{"nfs", "cifs", "iscsi", "multiprotocol", "nvme"}, false),
 				Default:      "nfs",
 			},
+			"security_style": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"unix", "ntfs", "mixed"}, false),
+			},
 			"share_name": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -158,6 +265,17 @@ func resourceCVOVolume() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"nvme_subsystem_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"nvme_host_nqns": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"comment": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -213,12 +331,10 @@ func resourceCVOVolume() *schema.Resource {
 										Type:         schema.TypeString,
 										ValidateFunc: validation.StringInSlice([]string{"5min", "8hour", "hourly", "daily", "weekly", "monthly"}, true),
 										Required:     true,
-										ForceNew:     true,
 									},
 									"retention": {
 										Type:     schema.TypeInt,
 										Required: true,
-										ForceNew: true,
 									},
 								},
 							},
@@ -369,6 +485,14 @@ func resourceCVOVolumeCreate(d *schema.ResourceData, meta interface{}) error {
 	workingEnvironmentType = weInfo.WorkingEnvironmentType
 	volume.WorkingEnvironmentType = workingEnvironmentType
 
+	if _, ok := d.GetOk("snaplock_type"); ok {
+		// snaplock volumes can only be created when WORM is enabled on the working environment
+		if err := checkSnaplockWorm(client, weInfo, clientID, isSaas, connectorIP); err != nil {
+			return err
+		}
+		volume.SnaplockInfo = expandSnaplockInfo(d)
+	}
+
 	if workingEnvironmentType != "ON_PREM" {
 		// Check if snapshot_nolicy_name exists
 		if !client.findSnapshotPolicy(weInfo.PublicID, quote.SnapshotPolicyName, clientID, isSaas, connectorIP) {
@@ -448,7 +572,13 @@ func resourceCVOVolumeCreate(d *schema.ResourceData, meta interface{}) error {
 				volume.ExportPolicyInfo.PolicyType = v.(string)
 				exportPolicyTypeOK = true
 			}
-			if !exportPolicyTypeOK || !exportPolicyIPOK || !exportPolicyNfsVersionOK || !exportPolicyRuleAccessControlOK || !exportPolicyRuleSuperUserOK {
+			// an export_policy_name without any rule parameters references an existing export policy,
+			// e.g. one managed by the netapp-cloudmanager_export_policy resource
+			if volume.ExportPolicyInfo.Name != "" && !exportPolicyIPOK && !exportPolicyNfsVersionOK && !exportPolicyRuleAccessControlOK && !exportPolicyRuleSuperUserOK {
+				if !exportPolicyTypeOK {
+					volume.ExportPolicyInfo.PolicyType = "custom"
+				}
+			} else if !exportPolicyTypeOK || !exportPolicyIPOK || !exportPolicyNfsVersionOK || !exportPolicyRuleAccessControlOK || !exportPolicyRuleSuperUserOK {
 				return fmt.Errorf("export_policy_type, export_policy_ip, export_policy_nfs_version, export_policy_rule_access_control and export_policy_rule_super_user are required for export policy")
 			}
 			rules := make([]ExportPolicyRule, len(policyIps))
@@ -481,6 +611,23 @@ func resourceCVOVolumeCreate(d *schema.ResourceData, meta interface{}) error {
 	volume.Size.Size = d.Get("size").(float64)
 	volume.Size.Unit = d.Get("unit").(string)
 	volumeProtocol := d.Get("volume_protocol").(string)
+	if d.Get("volume_style").(string) == "flexgroup" {
+		// constituents are placed on the aggregates of the flexgroup, not on the quoted aggregate
+		volume.VolumeStyle = "flexgroup"
+		volume.AggregateName = ""
+		volume.NewAggregate = false
+		createAggregateifNotExists = false
+		if v, ok := d.GetOk("aggregates"); ok {
+			aggregates := make([]string, 0, len(v.([]interface{})))
+			for _, x := range v.([]interface{}) {
+				aggregates = append(aggregates, x.(string))
+			}
+			volume.FlexGroupInfo.Aggregates = aggregates
+		}
+		if v, ok := d.GetOk("constituent_count"); ok {
+			volume.FlexGroupInfo.ConstituentCount = v.(int)
+		}
+	}
 	if v, ok := d.GetOk("comment"); ok {
 		volume.Comment = v.(string)
 	}
@@ -500,7 +647,19 @@ func resourceCVOVolumeCreate(d *schema.ResourceData, meta interface{}) error {
 		}
 		volume.VolumeTags = tags
 	}
-	if volumeProtocol == "cifs" {
+	if v, ok := d.GetOk("security_style"); ok {
+		volume.SecurityStyle = v.(string)
+	}
+	if v, ok := d.GetOk("anti_ransomware_state"); ok {
+		volume.AntiRansomwareState = v.(string)
+	}
+	if v, ok := d.GetOk("encryption"); ok && v.(bool) {
+		volume.Encryption.Enabled = true
+		if v, ok := d.GetOk("encryption_key_manager"); ok {
+			volume.Encryption.KeyManager = v.(string)
+		}
+	}
+	if volumeProtocol == "cifs" || volumeProtocol == "multiprotocol" {
 		exist, err := client.checkCifsExists(workingEnvironmentType, volume.WorkingEnvironmentID, volume.SvmName, clientID, isSaas, connectorIP)
 		if err != nil {
 			return err
@@ -558,6 +717,26 @@ func resourceCVOVolumeCreate(d *schema.ResourceData, meta interface{}) error {
 				volume.IscsiInfo.Igroups = igroups
 			}
 		}
+	} else if volumeProtocol == "nvme" {
+		isNewSubsystem, err := createNvmeVolumeHelper(d, meta, isSaas, connectorIP)
+		if err != nil {
+			return err
+		}
+		if v, ok := d.GetOk("os_name"); ok {
+			volume.NvmeInfo.OsName = v.(string)
+		}
+		subsystemName := d.Get("nvme_subsystem_name").(string)
+		if isNewSubsystem {
+			log.Print("Need to create nvme subsystem")
+			hostNqns := expandNvmeHostNqns(d.Get("nvme_host_nqns").(*schema.Set))
+			if len(hostNqns) == 0 {
+				return fmt.Errorf("nvme_host_nqns is required when creating new nvme subsystem")
+			}
+			volume.NvmeInfo.SubsystemCreationRequest.SubsystemName = subsystemName
+			volume.NvmeInfo.SubsystemCreationRequest.HostNqns = hostNqns
+		} else {
+			volume.NvmeInfo.SubsystemName = subsystemName
+		}
 	}
 	volume.WorkingEnvironmentType = workingEnvironmentType
 	err = client.createVolume(volume, createAggregateifNotExists, clientID, isSaas, connectorIP)
@@ -578,6 +757,25 @@ func resourceCVOVolumeCreate(d *schema.ResourceData, meta interface{}) error {
 			break
 		}
 	}
+	// track the aggregate created for the volume, so it can be deleted with its last volume
+	d.Set("aggregate_created", createAggregateifNotExists && volume.NewAggregate)
+
+	// deduplication and compression are not always applied on create, set them on the new volume
+	if (volume.EnableDeduplication && !createdVolume.EnableDeduplication) || (volume.EnableCompression && !createdVolume.EnableCompression) {
+		log.Print("Setting storage efficiency on the created volume")
+		efficiency := volumeRequest{}
+		efficiency.Name = createdVolume.Name
+		efficiency.WorkingEnvironmentID = volume.WorkingEnvironmentID
+		efficiency.WorkingEnvironmentType = volume.WorkingEnvironmentType
+		efficiency.SvmName = createdVolume.SvmName
+		efficiency.EnableThinProvisioning = volume.EnableThinProvisioning
+		efficiency.EnableDeduplication = volume.EnableDeduplication
+		efficiency.EnableCompression = volume.EnableCompression
+		err = client.updateVolume(efficiency, clientID, isSaas, connectorIP)
+		if err != nil {
+			return fmt.Errorf("volume '%s' was created successfully, but setting storage efficiency failed: %s", createdVolume.Name, err)
+		}
+	}
 
 	// Setup AVS integration if configured
 	if v, ok := d.GetOk("avs_integration"); ok {
@@ -659,22 +857,22 @@ func resourceCVOVolumeRead(d *schema.ResourceData, meta interface{}) error {
 			return err
 		}
 		log.Printf("### Fetching volume: %#v", volume)
+		d.Set("name", volume.Name)
 		if _, ok := d.GetOk("aggregate_name"); ok {
 			d.Set("aggregate_name", volume.AggregateName)
 		}
 		if _, ok := d.GetOk("snapshot_policy_name"); ok {
 			d.Set("snapshot_policy_name", volume.SnapshotPolicyName)
 		}
-		if _, ok := d.GetOk("enable_thin_provisioning"); ok {
-			d.Set("enable_thin_provisioning", volume.EnableThinProvisioning)
+		d.Set("enable_thin_provisioning", volume.EnableThinProvisioning)
+		d.Set("enable_deduplication", volume.EnableDeduplication)
+		d.Set("enable_compression", volume.EnableCompression)
+		d.Set("encryption", volume.Encryption.Enabled)
+		d.Set("encryption_key_manager", volume.Encryption.KeyManager)
+		setSnaplockAttributes(d, volume.SnaplockInfo)
+		if volume.AntiRansomwareState != "" {
+			d.Set("anti_ransomware_state", volume.AntiRansomwareState)
 		}
-		// setting this two in create are not working right now.
-		// if _, ok := d.GetOk("enable_deduplication"); ok {
-		// 	d.Set("enable_deduplication", volume.EnableDeduplication)
-		// }
-		// if _, ok := d.GetOk("enable_compression"); ok {
-		// 	d.Set("enable_compression", volume.EnableCompression)
-		// }
 		if _, ok := d.GetOk("export_policy_ip"); ok {
 			d.Set("export_policy_ip", volume.ExportPolicyInfo.Ips)
 		}
@@ -690,6 +888,16 @@ func resourceCVOVolumeRead(d *schema.ResourceData, meta interface{}) error {
 		if _, ok := d.GetOk("comment"); ok {
 			d.Set("comment", volume.Comment)
 		}
+		d.Set("security_style", volume.SecurityStyle)
+		if err := setFlexGroupAttributes(d, volume); err != nil {
+			return err
+		}
+		if d.Get("volume_protocol") == "nvme" {
+			d.Set("nvme_subsystem_name", volume.NvmeSubsystemName)
+			if err := readNvmeHostNqns(d, meta, volume.NvmeSubsystemName, weInfo, svm, isSaas, connectorIP); err != nil {
+				return err
+			}
+		}
 		if v, ok := d.GetOk("capacity_tier"); ok {
 			if v.(string) != "none" {
 				d.Set("capacity_tier", volume.CapacityTier)
@@ -703,14 +911,18 @@ func resourceCVOVolumeRead(d *schema.ResourceData, meta interface{}) error {
 		if _, ok := d.GetOk("export_policy_name"); ok {
 			d.Set("export_policy_name", volume.ExportPolicyInfo.Name)
 		}
-		if d.Get("unit") != "GB" {
-			d.Set("size", convertSizeUnit(volume.Size.Size, volume.Size.Unit, d.Get("unit").(string)))
-			d.Set("unit", d.Get("unit").(string))
+		if unit := d.Get("unit").(string); unit != "GB" && unit != "" {
+			size, err := convertSizeUnit(volume.Size.Size, volume.Size.Unit, unit)
+			if err != nil {
+				return fmt.Errorf("error reading volume size: %s", err)
+			}
+			d.Set("size", size)
+			d.Set("unit", unit)
 		} else {
 			d.Set("size", volume.Size.Size)
 			d.Set("unit", volume.Size.Unit)
 		}
-		if d.Get("volume_protocol") == "cifs" {
+		if d.Get("volume_protocol") == "cifs" || d.Get("volume_protocol") == "multiprotocol" {
 			if _, ok := d.GetOk("share_name"); ok {
 				if len(volume.ShareInfo) > 0 {
 					d.Set("share_name", volume.ShareInfo[0].ShareName)
@@ -737,10 +949,12 @@ func resourceCVOVolumeRead(d *schema.ResourceData, meta interface{}) error {
 			log.Print("Error reading volume")
 			return err
 		}
+		// the svm is only matched when it is given, as older import IDs do not contain it
+		_, hasSvm := d.GetOk("svm_name")
 		volFound := false
 		var volume volumeResponse
 		for _, vol := range res {
-			if vol.Name == d.Get("name") {
+			if vol.Name == d.Get("name") && (!hasSvm || vol.SvmName == svm) {
 				volFound = true
 				volume = vol
 				break
@@ -751,29 +965,39 @@ func resourceCVOVolumeRead(d *schema.ResourceData, meta interface{}) error {
 		}
 
 		log.Printf("### Fetching volume: %#v", volume)
-		if d.Get("volume_protocol") == "cifs" {
-			if _, ok := d.GetOk("share_name"); ok {
-				if len(volume.ShareInfo) > 0 {
-					d.Set("share_name", volume.ShareInfo[0].ShareName)
-				}
-			}
-			if _, ok := d.GetOk("permission"); ok {
-				if len(volume.ShareInfo) > 0 {
-					if len(volume.ShareInfo[0].AccessControlList) > 0 {
-						d.Set("permission", volume.ShareInfo[0].AccessControlList[0].Permission)
-					}
-				}
+		if importing {
+			// imported volumes are tracked by uuid, as the name can be modified in place
+			d.SetId(volume.ID)
+			d.Set("working_environment_name", weInfo.Name)
+		}
+		// the protocol is only derived on import, the configured protocol is kept otherwise
+		volumeProtocol := d.Get("volume_protocol").(string)
+		isImport := volumeProtocol == ""
+		if isImport {
+			volumeProtocol = getVolumeProtocol(volume)
+			d.Set("volume_protocol", volumeProtocol)
+		}
+		if volumeProtocol == "nvme" {
+			d.Set("nvme_subsystem_name", volume.NvmeSubsystemName)
+			if err := readNvmeHostNqns(d, meta, volume.NvmeSubsystemName, weInfo, volume.SvmName, isSaas, connectorIP); err != nil {
+				return err
 			}
-			if _, ok := d.GetOk("users"); ok {
-				if len(volume.ShareInfo) > 0 {
-					if len(volume.ShareInfo[0].AccessControlList) > 0 {
-						d.Set("users", volume.ShareInfo[0].AccessControlList[0].Users)
-					}
+		}
+		if volumeProtocol == "cifs" || volumeProtocol == "multiprotocol" {
+			if len(volume.ShareInfo) > 0 {
+				d.Set("share_name", volume.ShareInfo[0].ShareName)
+				if len(volume.ShareInfo[0].AccessControlList) > 0 {
+					d.Set("permission", volume.ShareInfo[0].AccessControlList[0].Permission)
+					d.Set("users", volume.ShareInfo[0].AccessControlList[0].Users)
 				}
 			}
 		}
+		if volumeProtocol == "nfs" || volumeProtocol == "multiprotocol" {
+			setVolumeExportPolicyRules(d, volume.ExportPolicyInfo, isImport)
+		}
 
 		d.Set("aggregate_name", volume.AggregateName)
+		d.Set("enable_thin_provisioning", volume.EnableThinProvisioning)
 		d.Set("export_policy_nfs_version", volume.ExportPolicyInfo.NfsVersion)
 		d.Set("export_policy_type", volume.ExportPolicyInfo.PolicyType)
 		d.Set("provider_volume_type", volume.ProviderVolumeType)
@@ -786,9 +1010,19 @@ func resourceCVOVolumeRead(d *schema.ResourceData, meta interface{}) error {
 		d.Set("svm_name", volume.SvmName)
 		d.Set("enable_deduplication", volume.EnableDeduplication)
 		d.Set("enable_compression", volume.EnableCompression)
+		d.Set("encryption", volume.Encryption.Enabled)
+		d.Set("encryption_key_manager", volume.Encryption.KeyManager)
+		setSnaplockAttributes(d, volume.SnaplockInfo)
+		if volume.AntiRansomwareState != "" {
+			d.Set("anti_ransomware_state", volume.AntiRansomwareState)
+		}
 		d.Set("tiering_policy", volume.TieringPolicy)
 		d.Set("snapshot_policy_name", volume.SnapshotPolicyName)
 		d.Set("capacity_tier", volume.CapacityTier)
+		d.Set("security_style", volume.SecurityStyle)
+		if err := setFlexGroupAttributes(d, volume); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -851,9 +1085,40 @@ func resourceCVOVolumeDelete(d *schema.ResourceData, meta interface{}) error {
 		log.Print("Error deleting volume")
 		return err
 	}
+
+	// only the aggregates created for the volume are deleted, flexgroup constituents are placed on existing aggregates
+	if client.DeleteEmptyAggregates && d.Get("aggregate_created").(bool) {
+		for _, aggregateName := range getVolumeAggregates(d) {
+			if _, err := client.deleteAggregateIfEmpty(weInfo.PublicID, weInfo.WorkingEnvironmentType, aggregateName, clientID, isSaas, connectorIP); err != nil {
+				log.Printf("Error deleting empty aggregate %s", aggregateName)
+				return err
+			}
+		}
+	}
 	return nil
 }
 
+// getVolumeAggregates returns the aggregate of a flexvol volume or the aggregates of a flexgroup volume
+func getVolumeAggregates(d *schema.ResourceData) []string {
+	var aggregates []string
+	if v := d.Get("aggregate_name").(string); v != "" {
+		aggregates = append(aggregates, v)
+	}
+	for _, x := range d.Get("aggregates").([]interface{}) {
+		found := false
+		for _, aggregate := range aggregates {
+			if aggregate == x.(string) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			aggregates = append(aggregates, x.(string))
+		}
+	}
+	return aggregates
+}
+
 func resourceCVOVolumeExists(d *schema.ResourceData, meta interface{}) (bool, error) {
 	log.Printf("Checking existence of volume: %s", d.Get("name").(string))
 	client := meta.(*Client)
@@ -927,6 +1192,158 @@ func resourceCVOVolumeUpdate(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	// Handle rename first, the other changes address the volume by its new name
+	if d.HasChange("name") {
+		weInfo, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+		if err != nil {
+			return fmt.Errorf("cannot find working environment")
+		}
+		old, new := d.GetChange("name")
+		log.Printf("Renaming volume %s to %s", old.(string), new.(string))
+		err = client.renameVolume(weInfo.PublicID, getSvmName(d, weInfo), old.(string), new.(string), clientID, isSaas, connectorIP)
+		if err != nil {
+			log.Print("Error renaming volume")
+			return err
+		}
+	}
+
+	// Handle aggregate changes, the volume is moved non-disruptively to the new aggregate
+	if d.HasChange("aggregate_name") {
+		weInfo, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+		if err != nil {
+			return fmt.Errorf("cannot find working environment")
+		}
+		old, new := d.GetChange("aggregate_name")
+		log.Printf("Moving volume %s from aggregate %s to aggregate %s", d.Get("name").(string), old.(string), new.(string))
+		request := volumeMoveRequest{}
+		request.TargetAggregate.Name = new.(string)
+		err = client.moveVolume(weInfo.PublicID, getSvmName(d, weInfo), d.Get("name").(string), request, clientID, isSaas, connectorIP)
+		if err != nil {
+			log.Print("Error moving volume")
+			return err
+		}
+		// the volume may have been the last one on the aggregate created for it
+		if client.DeleteEmptyAggregates && d.Get("aggregate_created").(bool) {
+			if _, err := client.deleteAggregateIfEmpty(weInfo.PublicID, weInfo.WorkingEnvironmentType, old.(string), clientID, isSaas, connectorIP); err != nil {
+				log.Printf("Error deleting empty aggregate %s", old.(string))
+				return err
+			}
+		}
+		d.Set("aggregate_created", false)
+	}
+
+	// Handle flexgroup changes, new constituents are added before the volume is grown
+	if d.HasChange("aggregates") || d.HasChange("constituent_count") || d.HasChange("size") || d.HasChange("unit") {
+		weInfo, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+		if err != nil {
+			return fmt.Errorf("cannot find working environment")
+		}
+		svm := getSvmName(d, weInfo)
+		if d.HasChange("aggregates") || d.HasChange("constituent_count") {
+			request := flexGroupExpandRequest{}
+			oldAggregates, newAggregates := d.GetChange("aggregates")
+			for _, x := range newAggregates.([]interface{}) {
+				found := false
+				for _, y := range oldAggregates.([]interface{}) {
+					if x.(string) == y.(string) {
+						found = true
+						break
+					}
+				}
+				if !found {
+					request.Aggregates = append(request.Aggregates, x.(string))
+				}
+			}
+			if d.HasChange("constituent_count") {
+				oldCount, newCount := d.GetChange("constituent_count")
+				request.ConstituentCount = newCount.(int) - oldCount.(int)
+			}
+			log.Printf("Expanding flexgroup %s: %#v", d.Get("name").(string), request)
+			err = client.expandFlexGroup(weInfo.PublicID, svm, d.Get("name").(string), request, clientID, isSaas, connectorIP)
+			if err != nil {
+				log.Print("Error expanding flexgroup")
+				return err
+			}
+		}
+		if d.HasChange("size") || d.HasChange("unit") {
+			request := volumeResizeRequest{}
+			request.Size.Size = d.Get("size").(float64)
+			request.Size.Unit = d.Get("unit").(string)
+			err = client.resizeVolume(weInfo.PublicID, svm, d.Get("name").(string), request, clientID, isSaas, connectorIP)
+			if err != nil {
+				log.Print("Error resizing volume")
+				return err
+			}
+		}
+	}
+
+	// Handle nvme host changes, hosts are added to or removed from the subsystem of the volume
+	if d.HasChange("nvme_host_nqns") {
+		log.Print("nvme host NQNs have changed")
+		weInfo, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+		if err != nil {
+			return fmt.Errorf("cannot find working environment")
+		}
+		subsystem := nvmeSubsystem{}
+		subsystem.SubsystemName = d.Get("nvme_subsystem_name").(string)
+		subsystem.WorkingEnvironmentID = weInfo.PublicID
+		subsystem.WorkingEnvironmentType = weInfo.WorkingEnvironmentType
+		subsystem.SvmName = getSvmName(d, weInfo)
+		old, new := d.GetChange("nvme_host_nqns")
+		added := expandNvmeHostNqns(new.(*schema.Set).Difference(old.(*schema.Set)))
+		removed := expandNvmeHostNqns(old.(*schema.Set).Difference(new.(*schema.Set)))
+		if len(added) > 0 {
+			err = client.addNvmeSubsystemHosts(subsystem, added, clientID, isSaas, connectorIP)
+			if err != nil {
+				return err
+			}
+		}
+		for _, hostNqn := range removed {
+			err = client.removeNvmeSubsystemHost(subsystem, hostNqn, clientID, isSaas, connectorIP)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Handle iscsi initiator changes, the igroup of the volume is updated in place
+	if d.HasChange("initiator") {
+		log.Print("initiators have changed")
+		weInfo, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+		if err != nil {
+			return fmt.Errorf("cannot find working environment")
+		}
+		igroups := d.Get("igroups").(*schema.Set)
+		if igroups.Len() != 1 {
+			return fmt.Errorf("initiator can only be modified when the volume has exactly one igroup")
+		}
+		svm := getSvmName(d, weInfo)
+		ig, err := client.getIgroup(weInfo.PublicID, weInfo.WorkingEnvironmentType, svm, igroups.List()[0].(string), clientID, isSaas, connectorIP)
+		if err != nil {
+			return err
+		}
+		if ig.IgroupName == "" {
+			return fmt.Errorf("cannot find igroup %s", igroups.List()[0].(string))
+		}
+		initiators := expandInitiator(d.Get("initiator").(*schema.Set))
+		err = client.ensureInitiators(initiators, weInfo.PublicID, weInfo.WorkingEnvironmentType, svm, clientID, isSaas, connectorIP)
+		if err != nil {
+			return err
+		}
+		request := igroupRequest{}
+		request.IgroupName = ig.IgroupName
+		request.OsType = ig.OsType
+		request.IgroupType = ig.IgroupType
+		request.Portsets = flattenIgroupPortsets(ig)
+		request.Initiators = getInitiatorIqns(initiators)
+		request.WorkingEnvironmentID = weInfo.PublicID
+		request.SvmName = svm
+		err = client.updateIgroup(request, clientID, isSaas, connectorIP)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Handle AVS integration changes
 	if d.HasChange("avs_integration") {
 		log.Print("AVS integration has changed")
@@ -974,10 +1391,7 @@ func resourceCVOVolumeUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 
 		// If only AVS integration changed, return early without calling updateVolume
-		if !d.HasChange("export_policy_ip") && !d.HasChange("export_policy_nfs_version") &&
-			!d.HasChange("export_policy_rule_super_user") && !d.HasChange("export_policy_rule_access_control") &&
-			!d.HasChange("permission") && !d.HasChange("users") && !d.HasChange("snapshot_policy_name") &&
-			!d.HasChange("tiering_policy") && !d.HasChange("comment") && !d.HasChange("sync_avs_hosts") {
+		if !hasVolumeUpdateChange(d) && !d.HasChange("sync_avs_hosts") {
 			return resourceCVOVolumeRead(d, meta)
 		}
 	}
@@ -1014,14 +1428,16 @@ func resourceCVOVolumeUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 
 		// If only sync_avs_hosts changed, return early without calling updateVolume
-		if !d.HasChange("avs_integration") && !d.HasChange("export_policy_ip") && !d.HasChange("export_policy_nfs_version") &&
-			!d.HasChange("export_policy_rule_super_user") && !d.HasChange("export_policy_rule_access_control") &&
-			!d.HasChange("permission") && !d.HasChange("users") && !d.HasChange("snapshot_policy_name") &&
-			!d.HasChange("tiering_policy") && !d.HasChange("comment") {
+		if !d.HasChange("avs_integration") && !hasVolumeUpdateChange(d) {
 			return resourceCVOVolumeRead(d, meta)
 		}
 	}
 
+	// Nothing left to send to updateVolume
+	if !hasVolumeUpdateChange(d) {
+		return resourceCVOVolumeRead(d, meta)
+	}
+
 	volume.Name = d.Get("name").(string)
 	if d.HasChange("export_policy_ip") || d.HasChange("export_policy_nfs_version") || d.HasChange("export_policy_rule_super_user") || d.HasChange("export_policy_rule_access_control") {
 		var exportPolicyTypeOK, exportPolicyIPOK, exportPolicyNfsVersionOK, exportPolicyRuleAccessControlOK, exportPolicyRuleSuperUserOK bool
@@ -1158,6 +1574,28 @@ func resourceCVOVolumeUpdate(d *schema.ResourceData, meta interface{}) error {
 	if d.HasChange("comment") {
 		volume.Comment = d.Get("comment").(string)
 	}
+	if d.HasChange("security_style") {
+		volume.SecurityStyle = d.Get("security_style").(string)
+	}
+	// storage efficiency is always sent, so an update does not turn it off
+	volume.EnableThinProvisioning = d.Get("enable_thin_provisioning").(bool)
+	volume.EnableDeduplication = d.Get("enable_deduplication").(bool)
+	volume.EnableCompression = d.Get("enable_compression").(bool)
+	if d.HasChange("encryption") && d.Get("encryption").(bool) {
+		volume.Encryption.Enabled = true
+		volume.Encryption.KeyManager = d.Get("encryption_key_manager").(string)
+	}
+	if d.HasChange("snaplock_default_retention") || d.HasChange("snaplock_minimum_retention") ||
+		d.HasChange("snaplock_maximum_retention") || d.HasChange("snaplock_autocommit_period") {
+		// WORM can be disabled on the working environment after the snaplock volume is created
+		if err := checkSnaplockWorm(client, weInfo, clientID, isSaas, connectorIP); err != nil {
+			return err
+		}
+		volume.SnaplockInfo = expandSnaplockInfo(d)
+	}
+	if d.HasChange("anti_ransomware_state") {
+		volume.AntiRansomwareState = d.Get("anti_ransomware_state").(string)
+	}
 	log.Printf("###Updating volume: %#v", volume)
 	err = client.updateVolume(volume, clientID, isSaas, connectorIP)
 	if err != nil {
@@ -1176,12 +1614,36 @@ func resourceVolumeImport(d *schema.ResourceData, meta interface{}) ([]*schema.R
 		return []*schema.ResourceData{}, fmt.Errorf("wrong option for deployment_mode: %s, options for deployment_mode are 'Standard' and 'Restricted'", parts[0])
 	}
 
+	// the volume can be given as working_environment_name/svm_name/name in a single part
+	if len(parts) > 2 && strings.Contains(parts[2], "/") {
+		path := strings.Split(parts[2], "/")
+		if len(path) != 3 {
+			return []*schema.ResourceData{}, fmt.Errorf("wrong format of volume: %s. Please input in the format 'working_environment_name/svm_name/name'", parts[2])
+		}
+		if parts[0] == "Standard" && len(parts) != 3 {
+			return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'deployment_mode,client_id,working_environment_name/svm_name/name'", d.Id())
+		}
+		if parts[0] == "Restricted" && len(parts) != 5 {
+			return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'deployment_mode,client_id,working_environment_name/svm_name/name,tenant_id,connector_ip'", d.Id())
+		}
+		d.Set("deployment_mode", parts[0])
+		d.Set("client_id", parts[1])
+		d.Set("working_environment_name", path[0])
+		d.Set("svm_name", path[1])
+		d.Set("name", path[2])
+		if parts[0] == "Restricted" {
+			d.Set("tenant_id", parts[3])
+			d.Set("connector_ip", parts[4])
+		}
+		return []*schema.ResourceData{d}, nil
+	}
+
 	if parts[0] == "Standard" && len(parts) != 4 {
-		return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'deployment_mode,client_id,working_environment_name,name'", d.Id())
+		return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'deployment_mode,client_id,working_environment_name/svm_name/name' or 'deployment_mode,client_id,working_environment_name,name'", d.Id())
 	}
 
 	if parts[0] == "Restricted" && len(parts) != 6 {
-		return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'deployment_mode,client_id,working_environment_name,name,tenant_id,connector_ip'", d.Id())
+		return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'deployment_mode,client_id,working_environment_name/svm_name/name,tenant_id,connector_ip' or 'deployment_mode,client_id,working_environment_name,name,tenant_id,connector_ip'", d.Id())
 	}
 
 	d.Set("deployment_mode", parts[0])
@@ -1210,15 +1672,30 @@ func resourceVolumeCustomizeDiff(diff *schema.ResourceDiff, v interface{}) error
 		}
 	}
 
-	// Check supported modification: Use volume name as an indication to know if this is a creation or modification
-	if !(diff.HasChange("name")) {
+	// Check supported modification: an existing resource has an ID, a new one does not
+	if diff.Id() != "" {
+		// snapshot_policy_name can be shared by several volumes or be a built-in policy, it is not edited through a volume.
+		// An imported volume has no snapshot_policy in its state, adding the block is accepted as nothing is changed.
+		if diff.HasChange("snapshot_policy") {
+			oldPolicy, _ := diff.GetChange("snapshot_policy")
+			if oldPolicy.(*schema.Set).Len() > 0 {
+				return fmt.Errorf("snapshot_policy is only used to create snapshot policy %s with the volume and can not be modified, use netapp-cloudmanager_snapshot_policy to manage its schedules", diff.Get("snapshot_policy_name").(string))
+			}
+		}
 		changeableParams := []string{"volume_protocol", "export_policy_type", "export_policy_ip",
 			"export_policy_name", "export_policy_nfs_version", "share_name", "permission", "users",
 			"tiering_policy", "snapshot_policy_name", "export_policy_rule_access_control",
 			"export_policy_rule_super_user", "comment", "deployment_mode", "connector_ip", "tenant_id",
-			"avs_integration", "sync_avs_hosts"}
+			"avs_integration", "sync_avs_hosts", "security_style", "nvme_host_nqns", "initiator", "aggregate_name", "aggregates", "constituent_count",
+			"size", "unit", "snapshot_policy", "enable_thin_provisioning", "enable_deduplication",
+			"enable_compression", "encryption", "snaplock_default_retention", "snaplock_minimum_retention",
+			"snaplock_maximum_retention", "snaplock_autocommit_period", "anti_ransomware_state"}
 		changedKeys := diff.GetChangedKeysPrefix("")
 		for _, key := range changedKeys {
+			// volume rename is an in-place update, matched exactly as many parameters contain "name"
+			if key == "name" {
+				continue
+			}
 			found := false
 			for _, changeable := range changeableParams {
 				// For nested attributes (e.g., avs_integration.0.field), check if key starts with the param
@@ -1232,32 +1709,55 @@ func resourceVolumeCustomizeDiff(diff *schema.ResourceDiff, v interface{}) error
 				return fmt.Errorf("%s parameter is not allowed to be modified", key)
 			}
 		}
+		if err := validateFlexGroupVolumeChange(diff); err != nil {
+			return err
+		}
+		if diff.HasChange("encryption") && !diff.Get("encryption").(bool) {
+			return fmt.Errorf("volume encryption can not be disabled")
+		}
+		// the key manager can only be selected when the volume is converted to an encrypted volume
+		if diff.HasChange("encryption_key_manager") && !diff.HasChange("encryption") {
+			return fmt.Errorf("encryption_key_manager parameter is not allowed to be modified")
+		}
+	} else if diff.Get("volume_style").(string) == "flexgroup" {
+		if _, ok := diff.GetOk("aggregate_name"); ok {
+			return fmt.Errorf("aggregate_name is not supported for flexgroup volumes, use aggregates instead")
+		}
+		if protocol := diff.Get("volume_protocol").(string); protocol == "iscsi" || protocol == "nvme" {
+			return fmt.Errorf("volume_protocol %s is not supported for flexgroup volumes", protocol)
+		}
+	}
+	// ARP monitors file activity, so it is only available on NAS volumes
+	if v, ok := diff.GetOk("anti_ransomware_state"); ok && v.(string) != "disabled" && diff.HasChange("anti_ransomware_state") {
+		if protocol := diff.Get("volume_protocol").(string); protocol == "iscsi" || protocol == "nvme" {
+			return fmt.Errorf("anti_ransomware_state %s is not supported for %s volumes", v.(string), protocol)
+		}
+	}
+	if _, ok := diff.GetOk("snaplock_type"); !ok {
+		for _, param := range []string{"snaplock_default_retention", "snaplock_minimum_retention", "snaplock_maximum_retention", "snaplock_autocommit_period"} {
+			if v, ok := diff.GetOk(param); ok && v.(string) != "" && diff.HasChange(param) {
+				return fmt.Errorf("%s requires snaplock_type", param)
+			}
+		}
+	}
+	if err := validateSnaplockRetention(diff.Get("snaplock_minimum_retention").(string), diff.Get("snaplock_default_retention").(string), diff.Get("snaplock_maximum_retention").(string)); err != nil {
+		return err
 	}
 
 	if diff.HasChange("volume_protocol") {
 		currentVolumeType, expectVolumeType := diff.GetChange("volume_protocol")
 		if currentVolumeType.(string) == "" {
-			if expectVolumeType.(string) == "nfs" {
-				if _, ok := diff.GetOk("export_policy_type"); !ok {
-					return fmt.Errorf("export_policy_type is required when volume type is nfs")
-				}
-				if _, ok := diff.GetOk("export_policy_ip"); !ok {
-					return fmt.Errorf("export_policy_ip is required when volume type is nfs")
-				}
-				if _, ok := diff.GetOk("export_policy_nfs_version"); !ok {
-					return fmt.Errorf("export_policy_nfs_version is required when volume type is nfs")
+			if expectVolumeType.(string) == "nfs" || expectVolumeType.(string) == "multiprotocol" {
+				if err := validateNfsVolumeParams(diff, expectVolumeType.(string)); err != nil {
+					return err
 				}
-			} else if expectVolumeType.(string) == "cifs" {
-				if _, ok := diff.GetOk("share_name"); !ok {
-					return fmt.Errorf("share_name is required when volume type is cifs")
-				}
-				if _, ok := diff.GetOk("permission"); !ok {
-					return fmt.Errorf("permission is required when volume type is cifs")
-				}
-				if _, ok := diff.GetOk("users"); !ok {
-					return fmt.Errorf("users is required when volume type is cifs")
+			}
+			if expectVolumeType.(string) == "cifs" || expectVolumeType.(string) == "multiprotocol" {
+				if err := validateCifsVolumeParams(diff, expectVolumeType.(string)); err != nil {
+					return err
 				}
-			} else if expectVolumeType.(string) == "iscsi" {
+			}
+			if expectVolumeType.(string) == "iscsi" {
 				if _, ok := diff.GetOk("igroups"); !ok {
 					return fmt.Errorf("igroups is required when volume type is iscsi")
 				}
@@ -1265,6 +1765,18 @@ func resourceVolumeCustomizeDiff(diff *schema.ResourceDiff, v interface{}) error
 					return fmt.Errorf("os_name is required when volume type is iscsi")
 				}
 			}
+			if expectVolumeType.(string) == "nvme" {
+				if _, ok := diff.GetOk("nvme_subsystem_name"); !ok {
+					return fmt.Errorf("nvme_subsystem_name is required when volume type is nvme")
+				}
+				osName, ok := diff.GetOk("os_name")
+				if !ok {
+					return fmt.Errorf("os_name is required when volume type is nvme")
+				}
+				if !isNvmeOsType(osName.(string)) {
+					return fmt.Errorf("os_name %s is not supported when volume type is nvme, options are %v", osName.(string), nvmeOsTypes)
+				}
+			}
 		} else {
 			return fmt.Errorf("volume type can not be changed")
 		}
@@ -1378,6 +1890,118 @@ func createIscsiVolumeHelper(d *schema.ResourceData, meta interface{}, isSaas bo
 	return isNewIgroup, isNewInitiator, nil
 }
 
+// createNvmeVolumeHelper checks whether the nvme subsystem exists. Missing host NQNs are added to an existing subsystem.
+func createNvmeVolumeHelper(d *schema.ResourceData, meta interface{}, isSaas bool, connectorIP string) (bool, error) {
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	workingEnvDetail, err := client.getWorkingEnvironmentDetail(d, clientID, isSaas, connectorIP)
+	if err != nil {
+		return false, fmt.Errorf("cannot find working environment")
+	}
+	subsystem := nvmeSubsystem{}
+	subsystem.SubsystemName = d.Get("nvme_subsystem_name").(string)
+	subsystem.WorkingEnvironmentID = workingEnvDetail.PublicID
+	subsystem.WorkingEnvironmentType = workingEnvDetail.WorkingEnvironmentType
+	subsystem.SvmName = getSvmName(d, workingEnvDetail)
+
+	res, err := client.getNvmeSubsystems(subsystem, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error reading nvme subsystems")
+		return false, err
+	}
+	for _, current := range res {
+		if current.SubsystemName != subsystem.SubsystemName {
+			continue
+		}
+		var missing []string
+		for _, hostNqn := range expandNvmeHostNqns(d.Get("nvme_host_nqns").(*schema.Set)) {
+			found := false
+			for _, currentNqn := range current.HostNqns {
+				if currentNqn == hostNqn {
+					found = true
+					break
+				}
+			}
+			if !found {
+				missing = append(missing, hostNqn)
+			}
+		}
+		if len(missing) > 0 {
+			err = client.addNvmeSubsystemHosts(subsystem, missing, clientID, isSaas, connectorIP)
+			if err != nil {
+				return false, err
+			}
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+// readNvmeHostNqns refreshes nvme_host_nqns from the subsystem the volume is mapped to. The subsystem can be
+// shared by several volumes, so only the hosts of the volume are kept, all hosts are read on import.
+func readNvmeHostNqns(d *schema.ResourceData, meta interface{}, subsystemName string, weInfo workingEnvironmentInfo, svm string, isSaas bool, connectorIP string) error {
+	if subsystemName == "" {
+		return nil
+	}
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+	request := nvmeSubsystem{}
+	request.WorkingEnvironmentID = weInfo.PublicID
+	request.WorkingEnvironmentType = weInfo.WorkingEnvironmentType
+	request.SvmName = svm
+	res, err := client.getNvmeSubsystems(request, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("Error reading nvme subsystems")
+		return err
+	}
+	for _, subsystem := range res {
+		if subsystem.SubsystemName == subsystemName {
+			d.Set("nvme_host_nqns", filterNvmeHostNqns(expandNvmeHostNqns(d.Get("nvme_host_nqns").(*schema.Set)), subsystem.HostNqns))
+			break
+		}
+	}
+	return nil
+}
+
+// filterNvmeHostNqns returns the hosts of the volume that are still on the subsystem, or all the hosts of the subsystem
+// if the volume has none
+func filterNvmeHostNqns(volumeHostNqns []string, subsystemHostNqns []string) []string {
+	if len(volumeHostNqns) == 0 {
+		return subsystemHostNqns
+	}
+	result := make([]string, 0, len(volumeHostNqns))
+	for _, hostNqn := range volumeHostNqns {
+		for _, subsystemHostNqn := range subsystemHostNqns {
+			if hostNqn == subsystemHostNqn {
+				result = append(result, hostNqn)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// nvmeOsTypes the host OS types supported by an nvme subsystem
+var nvmeOsTypes = []string{"aix", "linux", "vmware", "windows"}
+
+func isNvmeOsType(osName string) bool {
+	for _, osType := range nvmeOsTypes {
+		if osType == osName {
+			return true
+		}
+	}
+	return false
+}
+
+func expandNvmeHostNqns(set *schema.Set) []string {
+	hostNqns := make([]string, 0, set.Len())
+	for _, v := range set.List() {
+		hostNqns = append(hostNqns, v.(string))
+	}
+	return hostNqns
+}
+
 func expandInitiator(set *schema.Set) []initiator {
 	var initiators []initiator
 	for _, v := range set.List() {
@@ -1402,3 +2026,306 @@ func buildAvsOnVolumeRequest(avsConfig map[string]interface{}) avsOnVolumeReques
 		},
 	}
 }
+
+// volumeUpdateParams are the parameters sent to updateVolume
+var volumeUpdateParams = []string{"export_policy_ip", "export_policy_nfs_version", "export_policy_rule_super_user",
+	"export_policy_rule_access_control", "export_policy_name", "permission", "users", "snapshot_policy_name",
+	"tiering_policy", "comment", "security_style", "enable_thin_provisioning", "enable_deduplication",
+	"enable_compression", "encryption", "snaplock_default_retention", "snaplock_minimum_retention",
+	"snaplock_maximum_retention", "snaplock_autocommit_period", "anti_ransomware_state"}
+
+// hasVolumeUpdateChange returns true if any parameter handled by updateVolume has changed
+func hasVolumeUpdateChange(d *schema.ResourceData) bool {
+	for _, param := range volumeUpdateParams {
+		if d.HasChange(param) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateNfsVolumeParams checks the export policy parameters required when creating a nfs or multiprotocol volume
+func validateNfsVolumeParams(diff *schema.ResourceDiff, volumeType string) error {
+	_, hasExportPolicyIP := diff.GetOk("export_policy_ip")
+	_, hasExportPolicyName := diff.GetOk("export_policy_name")
+	if !hasExportPolicyIP && hasExportPolicyName {
+		// referencing an existing export policy by name, no rule parameters needed
+		log.Printf("volume references export policy %s", diff.Get("export_policy_name").(string))
+		return nil
+	}
+	if _, ok := diff.GetOk("export_policy_type"); !ok {
+		return fmt.Errorf("export_policy_type is required when volume type is %s", volumeType)
+	}
+	if !hasExportPolicyIP {
+		return fmt.Errorf("export_policy_ip is required when volume type is %s", volumeType)
+	}
+	if _, ok := diff.GetOk("export_policy_nfs_version"); !ok {
+		return fmt.Errorf("export_policy_nfs_version is required when volume type is %s", volumeType)
+	}
+	return nil
+}
+
+// validateCifsVolumeParams checks the share parameters required when creating a cifs or multiprotocol volume
+func validateCifsVolumeParams(diff *schema.ResourceDiff, volumeType string) error {
+	if _, ok := diff.GetOk("share_name"); !ok {
+		return fmt.Errorf("share_name is required when volume type is %s", volumeType)
+	}
+	if _, ok := diff.GetOk("permission"); !ok {
+		return fmt.Errorf("permission is required when volume type is %s", volumeType)
+	}
+	if _, ok := diff.GetOk("users"); !ok {
+		return fmt.Errorf("users is required when volume type is %s", volumeType)
+	}
+	return nil
+}
+
+// validateFlexGroupVolumeChange checks the size and placement changes of an existing volume.
+// Only flexgroup volumes can be grown in place, and constituents can not be removed.
+func validateFlexGroupVolumeChange(diff *schema.ResourceDiff) error {
+	if !diff.HasChange("size") && !diff.HasChange("unit") && !diff.HasChange("aggregates") && !diff.HasChange("constituent_count") {
+		return nil
+	}
+	if diff.Get("volume_style").(string) != "flexgroup" {
+		return fmt.Errorf("size, unit, aggregates and constituent_count can only be modified on flexgroup volumes")
+	}
+	if diff.HasChange("aggregate_name") {
+		return fmt.Errorf("aggregate_name is not supported for flexgroup volumes, use aggregates instead")
+	}
+	oldSize, newSize := diff.GetChange("size")
+	oldUnit, newUnit := diff.GetChange("unit")
+	newBytes, err := convertSizeUnit(newSize.(float64), newUnit.(string), "B")
+	if err != nil {
+		return err
+	}
+	oldBytes, err := convertSizeUnit(oldSize.(float64), oldUnit.(string), "B")
+	if err != nil {
+		return err
+	}
+	if newBytes < oldBytes {
+		return fmt.Errorf("flexgroup volume size can not be decreased")
+	}
+	oldAggregates, newAggregates := diff.GetChange("aggregates")
+	for _, x := range oldAggregates.([]interface{}) {
+		found := false
+		for _, y := range newAggregates.([]interface{}) {
+			if x.(string) == y.(string) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("aggregate %s can not be removed from flexgroup volume", x.(string))
+		}
+	}
+	oldCount, newCount := diff.GetChange("constituent_count")
+	if newCount.(int) < oldCount.(int) {
+		return fmt.Errorf("constituent_count of flexgroup volume can not be decreased")
+	}
+	return nil
+}
+
+// setFlexGroupAttributes sets the volume style and the constituents of a flexgroup volume
+func setFlexGroupAttributes(d *schema.ResourceData, volume volumeResponse) error {
+	if volume.VolumeStyle != "flexgroup" {
+		d.Set("volume_style", "flexvol")
+		return nil
+	}
+	d.Set("volume_style", "flexgroup")
+	aggregates := make([]string, 0)
+	constituents := make([]interface{}, 0, len(volume.Constituents))
+	for _, constituent := range volume.Constituents {
+		found := false
+		for _, aggr := range aggregates {
+			if aggr == constituent.AggregateName {
+				found = true
+				break
+			}
+		}
+		if !found {
+			aggregates = append(aggregates, constituent.AggregateName)
+		}
+		entry := make(map[string]interface{})
+		entry["name"] = constituent.Name
+		entry["aggregate_name"] = constituent.AggregateName
+		entry["size"] = constituent.Size.Size
+		entry["unit"] = constituent.Size.Unit
+		constituents = append(constituents, entry)
+	}
+	// keep the configured order of the aggregates when they match the constituents
+	configured := d.Get("aggregates").([]interface{})
+	sameAggregates := len(configured) == len(aggregates)
+	for _, x := range configured {
+		found := false
+		for _, aggr := range aggregates {
+			if x.(string) == aggr {
+				found = true
+				break
+			}
+		}
+		sameAggregates = sameAggregates && found
+	}
+	if !sameAggregates {
+		d.Set("aggregates", aggregates)
+	}
+	d.Set("constituent_count", len(volume.Constituents))
+	if err := d.Set("constituents", constituents); err != nil {
+		return fmt.Errorf("error reading volume constituents: %s", err)
+	}
+	return nil
+}
+
+// setVolumeExportPolicyRules sets the export policy rule parameters from the export policy of the volume.
+// They are only read when configured or on import, a volume can reference an existing policy by name only.
+func setVolumeExportPolicyRules(d *schema.ResourceData, exportPolicy ExportPolicyInfoResponse, isImport bool) {
+	if _, ok := d.GetOk("export_policy_ip"); ok || isImport {
+		ips := exportPolicy.Ips
+		if len(ips) == 0 {
+			for _, rule := range exportPolicy.Rules {
+				ips = append(ips, rule.Ips...)
+			}
+		}
+		d.Set("export_policy_ip", ips)
+	}
+	if len(exportPolicy.Rules) == 0 {
+		return
+	}
+	if _, ok := d.GetOk("export_policy_rule_access_control"); ok || isImport {
+		d.Set("export_policy_rule_access_control", exportPolicy.Rules[0].RuleAccessControl)
+	}
+	if _, ok := d.GetOkExists("export_policy_rule_super_user"); ok || isImport {
+		d.Set("export_policy_rule_super_user", exportPolicy.Rules[0].SuperUser)
+	}
+}
+
+// expandSnaplockInfo builds the SnapLock settings of the volume from the configuration
+func expandSnaplockInfo(d *schema.ResourceData) snaplockInfo {
+	info := snaplockInfo{}
+	info.SnaplockType = d.Get("snaplock_type").(string)
+	info.DefaultRetention = d.Get("snaplock_default_retention").(string)
+	info.MinimumRetention = d.Get("snaplock_minimum_retention").(string)
+	info.MaximumRetention = d.Get("snaplock_maximum_retention").(string)
+	info.AutocommitPeriod = d.Get("snaplock_autocommit_period").(string)
+	return info
+}
+
+// checkSnaplockWorm checks WORM is enabled on the working environment of a snaplock volume
+func checkSnaplockWorm(client *Client, weInfo workingEnvironmentInfo, clientID string, isSaas bool, connectorIP string) error {
+	cvoProperties, err := client.getCVOProperties(weInfo.PublicID, clientID, isSaas, connectorIP)
+	if err != nil {
+		return err
+	}
+	if !cvoProperties.OntapClusterProperties.WormEnabled {
+		return fmt.Errorf("snaplock volumes require WORM to be enabled on working environment %s", weInfo.Name)
+	}
+	return nil
+}
+
+// snaplockPeriodRegex matches an ISO 8601 duration such as P30D, P1Y6M or PT4H
+var snaplockPeriodRegex = regexp.MustCompile(`^P(\d+Y)?(\d+M)?(\d+D)?(T(\d+H)?(\d+M)?(\d+S)?)?$`)
+
+// validateSnaplockPeriod accepts an ISO 8601 duration or one of the given keywords
+func validateSnaplockPeriod(keywords ...string) schema.SchemaValidateFunc {
+	return func(i interface{}, k string) (s []string, es []error) {
+		v, ok := i.(string)
+		if !ok {
+			es = append(es, fmt.Errorf("expected type of %s to be string", k))
+			return
+		}
+		for _, keyword := range keywords {
+			if v == keyword {
+				return
+			}
+		}
+		if !snaplockPeriodRegex.MatchString(v) || v == "P" || strings.HasSuffix(v, "T") {
+			es = append(es, fmt.Errorf("invalid format of %s: %s, expected an ISO 8601 duration such as P30D or PT4H, or one of %v", k, v, keywords))
+		}
+		return
+	}
+}
+
+// getSnaplockPeriodSeconds returns the approximate length of a snaplock period in seconds, a year is 365 days and
+// a month 30 days. Keywords other than infinite can not be compared and return false.
+func getSnaplockPeriodSeconds(period string) (int64, bool) {
+	if period == "infinite" {
+		return math.MaxInt64, true
+	}
+	matches := snaplockPeriodRegex.FindStringSubmatch(period)
+	if matches == nil {
+		return 0, false
+	}
+	units := []struct {
+		index   int
+		seconds int64
+	}{{1, 365 * 86400}, {2, 30 * 86400}, {3, 86400}, {5, 3600}, {6, 60}, {7, 1}}
+	var seconds int64
+	for _, unit := range units {
+		if matches[unit.index] == "" {
+			continue
+		}
+		value, err := strconv.ParseInt(strings.TrimRight(matches[unit.index], "YMDHS"), 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		seconds += value * unit.seconds
+	}
+	return seconds, true
+}
+
+// validateSnaplockRetention checks minimum <= default <= maximum for the retention periods which are set
+func validateSnaplockRetention(minimum string, defaultRetention string, maximum string) error {
+	periods := []struct {
+		name  string
+		value string
+	}{{"snaplock_minimum_retention", minimum}, {"snaplock_default_retention", defaultRetention}, {"snaplock_maximum_retention", maximum}}
+	for i := 0; i < len(periods); i++ {
+		lower, ok := getSnaplockPeriodSeconds(periods[i].value)
+		if !ok {
+			continue
+		}
+		for j := i + 1; j < len(periods); j++ {
+			upper, ok := getSnaplockPeriodSeconds(periods[j].value)
+			if ok && lower > upper {
+				return fmt.Errorf("%s %s can not be greater than %s %s", periods[i].name, periods[i].value, periods[j].name, periods[j].value)
+			}
+		}
+	}
+	return nil
+}
+
+// setSnaplockAttributes sets the SnapLock settings read from the volume
+func setSnaplockAttributes(d *schema.ResourceData, info snaplockInfo) {
+	if info.SnaplockType == "" || info.SnaplockType == "non_snaplock" {
+		d.Set("snaplock_type", "")
+		return
+	}
+	d.Set("snaplock_type", info.SnaplockType)
+	d.Set("snaplock_default_retention", info.DefaultRetention)
+	d.Set("snaplock_minimum_retention", info.MinimumRetention)
+	d.Set("snaplock_maximum_retention", info.MaximumRetention)
+	d.Set("snaplock_autocommit_period", info.AutocommitPeriod)
+}
+
+// getVolumeProtocol derives the volume protocol from the export policy rules and shares of the volume.
+// Every volume has an export policy, such as default, so only a policy with rules or NFS versions is NFS access.
+func getVolumeProtocol(volume volumeResponse) string {
+	hasNfs := len(volume.ExportPolicyInfo.Ips) > 0 || len(volume.ExportPolicyInfo.NfsVersion) > 0
+	for _, rule := range volume.ExportPolicyInfo.Rules {
+		if len(rule.Ips) > 0 || len(rule.NfsVersion) > 0 {
+			hasNfs = true
+		}
+	}
+	hasCifs := len(volume.ShareInfo) > 0
+	if volume.IscsiEnabled {
+		return "iscsi"
+	}
+	if volume.NvmeEnabled {
+		return "nvme"
+	}
+	if hasNfs && hasCifs {
+		return "multiprotocol"
+	}
+	if hasCifs {
+		return "cifs"
+	}
+	return "nfs"
+}
@@ -0,0 +1,228 @@
+package cloudmanager
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceVolumeBackup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVolumeBackupCreate,
+		Read:   resourceVolumeBackupRead,
+		Delete: resourceVolumeBackupDelete,
+		Exists: resourceVolumeBackupExists,
+		Update: resourceVolumeBackupUpdate,
+		Importer: &schema.ResourceImporter{
+			State: resourceVolumeBackupImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"volume_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"policy_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"volume_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceVolumeBackupCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Enabling volume backup: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, true, "")
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+	cbs := cbsRequest{}
+	cbs.WorkingEnvironmentID = workingEnv.PublicID
+	cbs.AccountID = d.Get("account_id").(string)
+	volumeID := d.Get("volume_id").(string)
+
+	backupVolume, err := client.getCBSVolumeByID(cbs, volumeID, clientID)
+	if err != nil {
+		log.Print("Error retrieving volume backup details")
+		return err
+	}
+	if backupVolume.ID != "" {
+		return fmt.Errorf("volume %s is already backed up, import it into this resource to manage its backup", volumeID)
+	}
+
+	volume := buildVolumeBackupRequest(d)
+	volumesIDNameMap := map[string]map[string]string{volumeID: {"name": volumeID}}
+	_, err = client.enableBackupForSingleORMultipleVolumes(cbs, cbsVolumeRequest{Volume: []cbsVolume{volume}}, clientID, volumesIDNameMap)
+	if err != nil {
+		log.Print("Error enabling cloud backup on the volume ", volumeID)
+		return err
+	}
+	d.SetId(volumeID)
+
+	return resourceVolumeBackupRead(d, meta)
+}
+
+func resourceVolumeBackupRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Reading volume backup: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, true, "")
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+	cbs := cbsRequest{}
+	cbs.WorkingEnvironmentID = workingEnv.PublicID
+	cbs.AccountID = d.Get("account_id").(string)
+	volumeID := d.Get("volume_id").(string)
+
+	backupVolume, err := client.getCBSVolumeByID(cbs, volumeID, clientID)
+	if err != nil {
+		log.Print("Error retrieving volume backup details")
+		return err
+	}
+	if backupVolume.ID == "" {
+		return fmt.Errorf("expected backup of volume %v, Response could not find", volumeID)
+	}
+
+	if strings.Contains(d.Id(), ",") {
+		d.SetId(backupVolume.ID)
+		d.Set("working_environment_name", workingEnv.Name)
+	}
+	d.Set("volume_name", backupVolume.Name)
+	d.Set("policy_name", backupVolume.BackupPolicy.Name)
+	if backupVolume.Mode != "" {
+		d.Set("mode", backupVolume.Mode)
+	}
+
+	return nil
+}
+
+func resourceVolumeBackupUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Updating volume backup: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, true, "")
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+	cbs := cbsRequest{}
+	cbs.WorkingEnvironmentID = workingEnv.PublicID
+	cbs.AccountID = d.Get("account_id").(string)
+
+	if d.HasChange("policy_name") || d.HasChange("mode") {
+		err = client.updateCBSVolume(cbs, buildVolumeBackupRequest(d), clientID)
+		if err != nil {
+			log.Print("Error updating cloud backup on the volume ", d.Get("volume_id").(string))
+			return err
+		}
+	}
+
+	return resourceVolumeBackupRead(d, meta)
+}
+
+func resourceVolumeBackupDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Deactivating volume backup: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, true, "")
+	if err != nil {
+		return fmt.Errorf("cannot find working environment")
+	}
+	cbs := cbsRequest{}
+	cbs.WorkingEnvironmentID = workingEnv.PublicID
+	cbs.AccountID = d.Get("account_id").(string)
+
+	// the existing backups of the volume are kept
+	err = client.deactivateCBSVolume(cbs, d.Get("volume_id").(string), clientID)
+	if err != nil {
+		log.Print("Error deactivating cloud backup on the volume ", d.Get("volume_id").(string))
+		return err
+	}
+	return nil
+}
+
+func resourceVolumeBackupExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	log.Printf("Checking existence of volume backup: %#v", d)
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID, true, "")
+	if err != nil {
+		return false, fmt.Errorf("cannot find working environment")
+	}
+	cbs := cbsRequest{}
+	cbs.WorkingEnvironmentID = workingEnv.PublicID
+	cbs.AccountID = d.Get("account_id").(string)
+
+	backupVolume, err := client.getCBSVolumeByID(cbs, d.Get("volume_id").(string), clientID)
+	if err != nil {
+		log.Print("Error getting volume backup")
+		return false, err
+	}
+	if backupVolume.ID == "" {
+		d.SetId("")
+		return false, nil
+	}
+	return true, nil
+}
+
+func resourceVolumeBackupImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ",")
+	if len(parts) != 4 {
+		return []*schema.ResourceData{}, fmt.Errorf("wrong format of resource: %s. Please input in the format 'client_id,account_id,working_environment_name,volume_id'", d.Id())
+	}
+
+	d.Set("client_id", parts[0])
+	d.Set("account_id", parts[1])
+	d.Set("working_environment_name", parts[2])
+	d.Set("volume_id", parts[3])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func buildVolumeBackupRequest(d *schema.ResourceData) cbsVolume {
+	volume := cbsVolume{}
+	volume.VolumeID = d.Get("volume_id").(string)
+	volume.Mode = d.Get("mode").(string)
+	volume.BackupPolicy.Name = d.Get("policy_name").(string)
+	return volume
+}
@@ -2,6 +2,7 @@ package cloudmanager
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
@@ -468,3 +469,111 @@ func testAccGCPVolumeConfigCreateIscsi(clientID string, workingEnvironmentName s
 		working_environment_name = "%s"
 	}`, clientID, workingEnvironmentName)
 }
+
+func TestGetVolumeProtocol(t *testing.T) {
+	defaultPolicy := ExportPolicyInfoResponse{Name: "default"}
+	nfsPolicy := ExportPolicyInfoResponse{
+		Name:  "export-svm_acccvo-nfs_vol",
+		Rules: []ExportPolicyRule{{RuleAccessControl: "readwrite", Ips: []string{"10.0.0.0/16"}, NfsVersion: []string{"nfs4"}}},
+	}
+	share := []shareInfoResponse{{ShareName: "cifs_share"}}
+	cases := []struct {
+		name     string
+		volume   volumeResponse
+		expected string
+	}{
+		{"cifs with default policy", volumeResponse{ExportPolicyInfo: defaultPolicy, ShareInfo: share}, "cifs"},
+		{"nfs", volumeResponse{ExportPolicyInfo: nfsPolicy}, "nfs"},
+		{"nfs with policy ips", volumeResponse{ExportPolicyInfo: ExportPolicyInfoResponse{Name: "custom", Ips: []string{"10.0.0.0/16"}}}, "nfs"},
+		{"multiprotocol", volumeResponse{ExportPolicyInfo: nfsPolicy, ShareInfo: share}, "multiprotocol"},
+		{"iscsi", volumeResponse{ExportPolicyInfo: defaultPolicy, IscsiEnabled: true}, "iscsi"},
+		{"nvme", volumeResponse{ExportPolicyInfo: defaultPolicy, NvmeEnabled: true}, "nvme"},
+	}
+	for _, c := range cases {
+		if protocol := getVolumeProtocol(c.volume); protocol != c.expected {
+			t.Errorf("%s: expected volume protocol %s, got %s", c.name, c.expected, protocol)
+		}
+	}
+}
+
+func TestFilterNvmeHostNqns(t *testing.T) {
+	subsystemHostNqns := []string{"nqn.host1", "nqn.host2", "nqn.host3"}
+	cases := []struct {
+		name     string
+		volume   []string
+		expected []string
+	}{
+		{"import reads all hosts", []string{}, subsystemHostNqns},
+		{"hosts of other volumes are ignored", []string{"nqn.host1"}, []string{"nqn.host1"}},
+		{"removed host is dropped", []string{"nqn.host1", "nqn.host4"}, []string{"nqn.host1"}},
+	}
+	for _, c := range cases {
+		if hostNqns := filterNvmeHostNqns(c.volume, subsystemHostNqns); !reflect.DeepEqual(hostNqns, c.expected) {
+			t.Errorf("%s: expected host nqns %v, got %v", c.name, c.expected, hostNqns)
+		}
+	}
+}
+
+func TestValidateSnaplockPeriod(t *testing.T) {
+	validate := validateSnaplockPeriod("infinite")
+	for _, period := range []string{"P30D", "P1Y6M", "PT4H", "P1DT12H", "infinite"} {
+		if _, errs := validate(period, "snaplock_default_retention"); len(errs) != 0 {
+			t.Errorf("expected %s to be valid, got %v", period, errs)
+		}
+	}
+	for _, period := range []string{"30D", "P", "PT", "P1H", "none", "1 year"} {
+		if _, errs := validate(period, "snaplock_default_retention"); len(errs) == 0 {
+			t.Errorf("expected %s to be invalid", period)
+		}
+	}
+}
+
+func TestValidateSnaplockRetention(t *testing.T) {
+	cases := []struct {
+		minimum  string
+		def      string
+		maximum  string
+		hasError bool
+	}{
+		{"P1D", "P30D", "P30Y", false},
+		{"P1D", "P30D", "infinite", false},
+		{"", "P30D", "", false},
+		{"P1D", "min", "P1Y", false},
+		{"P1Y", "P30D", "P30Y", true},
+		{"P1D", "P2Y", "P1Y", true},
+		{"infinite", "", "P30Y", true},
+		{"PT48H", "P1D", "", true},
+	}
+	for _, c := range cases {
+		err := validateSnaplockRetention(c.minimum, c.def, c.maximum)
+		if (err != nil) != c.hasError {
+			t.Errorf("minimum %s, default %s, maximum %s: expected error %v, got %v", c.minimum, c.def, c.maximum, c.hasError, err)
+		}
+	}
+}
+
+func TestConvertSizeUnit(t *testing.T) {
+	cases := []struct {
+		size     float64
+		from     string
+		to       string
+		expected float64
+	}{
+		{1, "GB", "MB", 1024},
+		{2048, "MB", "GB", 2},
+		{1, "TB", "B", 1 << 40},
+		{3, "Byte", "B", 3},
+		{1024, "kb", "MB", 1},
+	}
+	for _, c := range cases {
+		size, err := convertSizeUnit(c.size, c.from, c.to)
+		if err != nil {
+			t.Errorf("converting %v %s to %s: unexpected error %s", c.size, c.from, c.to, err)
+		} else if size != c.expected {
+			t.Errorf("converting %v %s to %s: expected %v, got %v", c.size, c.from, c.to, c.expected, size)
+		}
+	}
+	if _, err := convertSizeUnit(1, "PB", "GB"); err == nil {
+		t.Error("expected an error for an unknown unit")
+	}
+}
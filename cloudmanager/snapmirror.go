@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/fatih/structs"
 )
@@ -18,8 +19,8 @@ type replicationRequest struct {
 	SourceWorkingEnvironmentID      string   `structs:"sourceWorkingEnvironmentId"`
 	DestinationWorkingEnvironmentID string   `structs:"destinationWorkingEnvironmentId"`
 	DestinationFsxID                string   `structs:"destinationFsxId"`
-	SourceInterclusterLifIps        []string `structs:"sourceInterclusterLifIps"`
-	DestinationInterclusterLifIps   []string `structs:"destinationInterclusterLifIps"`
+	SourceInterclusterLifIps        []string `structs:"sourceInterclusterLifIps,omitempty"`
+	DestinationInterclusterLifIps   []string `structs:"destinationInterclusterLifIps,omitempty"`
 	PolicyName                      string   `structs:"policyName"`
 	ScheduleName                    string   `structs:"scheduleName,omitempty"`
 	MaxTransferRate                 int      `structs:"maxTransferRate,omitempty"`
@@ -39,6 +40,13 @@ type replicationVolume struct {
 	Throughput                    int     `structs:"throughput,omitempty"`
 }
 
+// updateSnapMirrorRequest the modifiable settings of a snapmirror relationship
+type updateSnapMirrorRequest struct {
+	PolicyName      string `structs:"policyName,omitempty"`
+	ScheduleName    string `structs:"scheduleName,omitempty"`
+	MaxTransferRate int    `structs:"maxTransferRate"`
+}
+
 type interclusterlif struct {
 	Interclusterlif     []interClusterLifsAddress `json:"interClusterLifs"`
 	PeerInterclusterlif []interClusterLifsAddress `json:"peerInterClusterLifs"`
@@ -49,11 +57,17 @@ type interClusterLifsAddress struct {
 }
 
 type snapMirrorStatusResponse struct {
-	Source          relationshipEndpoint `json:"source"`
-	Destination     relationshipEndpoint `json:"destination"`
-	Policy          string               `json:"policy"`
-	Schedule        string               `json:"schedule"`
-	MaxTransferRate sizeUnit             `json:"maxTransferRate"`
+	Source             relationshipEndpoint `json:"source"`
+	Destination        relationshipEndpoint `json:"destination"`
+	Policy             string               `json:"policy"`
+	Schedule           string               `json:"schedule"`
+	MaxTransferRate    sizeUnit             `json:"maxTransferRate"`
+	MirrorState        string               `json:"mirrorState"`
+	RelationshipStatus string               `json:"relationshipStatus"`
+	Healthy            bool                 `json:"healthy"`
+	LagTime            sizeUnit             `json:"lagTime"`
+	LastTransferSize   sizeUnit             `json:"lastTransferSize"`
+	LastTransferEnd    int64                `json:"lastTransferEndTimestamp"`
 }
 
 type relationshipEndpoint struct {
@@ -92,13 +106,16 @@ type relationshipEndpointBasic struct {
 	ID string `json:"id"`
 }
 
-func (c *Client) getInterclusterlifs(snapMirror snapMirrorRequest, clientID string, isSaas bool, connectorIP string) (interclusterlif, error) {
-	var destinationWEID string
+// getSnapMirrorDestinationID returns the destination working environment ID, or the FSx ID for a FSx destination
+func getSnapMirrorDestinationID(snapMirror snapMirrorRequest) string {
 	if snapMirror.ReplicationRequest.DestinationFsxID != "" {
-		destinationWEID = snapMirror.ReplicationRequest.DestinationFsxID
-	} else {
-		destinationWEID = snapMirror.ReplicationRequest.DestinationWorkingEnvironmentID
+		return snapMirror.ReplicationRequest.DestinationFsxID
 	}
+	return snapMirror.ReplicationRequest.DestinationWorkingEnvironmentID
+}
+
+func (c *Client) getInterclusterlifs(snapMirror snapMirrorRequest, clientID string, isSaas bool, connectorIP string) (interclusterlif, error) {
+	destinationWEID := getSnapMirrorDestinationID(snapMirror)
 
 	baseURL := fmt.Sprintf("/occm/api/replication/intercluster-lifs?peerWorkingEnvironmentId=%s&workingEnvironmentId=%s", destinationWEID, snapMirror.ReplicationRequest.SourceWorkingEnvironmentID)
 
@@ -135,11 +152,31 @@ func (c *Client) buildSnapMirrorCreate(snapMirror snapMirrorRequest, sourceWorki
 	}
 	c.Token = accessTokenResult.Token
 
-	interclusterlifsResponse, err := c.getInterclusterlifs(snapMirror, clientID, isSaas, connectorIP)
+	// an existing cluster peer is reused, the intercluster LIFs are only sent to negotiate a new peering
+	destinationWEID := getSnapMirrorDestinationID(snapMirror)
+	clusterPeer, err := c.getClusterPeer(snapMirror.ReplicationRequest.SourceWorkingEnvironmentID, destinationWEID, clientID, isSaas, connectorIP)
 	if err != nil {
-		log.Print("intercluster-lifs reading failed")
+		log.Print("cluster peers reading failed")
 		return snapMirrorRequest{}, err
 	}
+	if clusterPeer.PeerClusterName != "" {
+		if clusterPeer.Availability != "" && clusterPeer.Availability != "available" {
+			return snapMirrorRequest{}, fmt.Errorf("cluster peer %s of working environment %s is %s, the peering must be available to create the snapmirror relationship", clusterPeer.PeerClusterName, snapMirror.ReplicationRequest.SourceWorkingEnvironmentID, clusterPeer.Availability)
+		}
+		log.Printf("[INFO] reusing cluster peer %s of working environment %s", clusterPeer.PeerClusterName, snapMirror.ReplicationRequest.SourceWorkingEnvironmentID)
+	} else {
+		interclusterlifsResponse, err := c.getInterclusterlifs(snapMirror, clientID, isSaas, connectorIP)
+		if err != nil {
+			log.Print("intercluster-lifs reading failed")
+			return snapMirrorRequest{}, err
+		}
+		sourceInterclusterLifIP, destinationInterclusterLifIP, err := getInterclusterLifIps(interclusterlifsResponse, snapMirror.ReplicationRequest.SourceWorkingEnvironmentID, destinationWEID)
+		if err != nil {
+			return snapMirrorRequest{}, err
+		}
+		snapMirror.ReplicationRequest.SourceInterclusterLifIps = []string{sourceInterclusterLifIP}
+		snapMirror.ReplicationRequest.DestinationInterclusterLifIps = []string{destinationInterclusterLifIP}
+	}
 
 	var volumeSource []volumeResponse
 	volumeS := volumeRequest{}
@@ -148,7 +185,6 @@ func (c *Client) buildSnapMirrorCreate(snapMirror snapMirrorRequest, sourceWorki
 	} else {
 		volumeS.WorkingEnvironmentID = snapMirror.ReplicationRequest.SourceWorkingEnvironmentID
 	}
-	volumeS.WorkingEnvironmentID = snapMirror.ReplicationRequest.SourceWorkingEnvironmentID
 	volumeS.Name = snapMirror.ReplicationVolume.SourceVolumeName
 
 	if sourceWorkingEnvironmentType != "ON_PREM" {
@@ -213,12 +249,6 @@ func (c *Client) buildSnapMirrorCreate(snapMirror snapMirrorRequest, sourceWorki
 		}
 	}
 
-	var sourceInterclusterLifIps []string
-	var destinationInterclusterLifIps []string
-	sourceInterclusterLifIps = append(sourceInterclusterLifIps, interclusterlifsResponse.Interclusterlif[0].Address)
-	snapMirror.ReplicationRequest.SourceInterclusterLifIps = sourceInterclusterLifIps
-	destinationInterclusterLifIps = append(destinationInterclusterLifIps, interclusterlifsResponse.PeerInterclusterlif[0].Address)
-	snapMirror.ReplicationRequest.DestinationInterclusterLifIps = destinationInterclusterLifIps
 	snapMirror.ReplicationVolume.SourceSvmName = sourceVolume.SvmName
 	snapMirror.ReplicationVolume.SourceVolumeName = sourceVolume.Name
 
@@ -445,23 +475,34 @@ func (c *Client) findSnapMirrorByDestinationVolume(destinationVolumeName string,
 		return nil, err
 	}
 
+	var found []snapMirrorStatusResponse
 	for _, relationship := range relationships {
 		if relationship.Destination.VolumeName == destinationVolumeName {
-			return &relationship, nil
+			found = append(found, relationship)
 		}
 	}
+	if len(found) > 1 {
+		return nil, fmt.Errorf("found %d snapmirror relationships for destination volume: %s, the destination volume name must be unique to import the relationship", len(found), destinationVolumeName)
+	}
+	if len(found) == 1 {
+		return &found[0], nil
+	}
 
 	return nil, fmt.Errorf("snapmirror relationship not found for destination volume: %s", destinationVolumeName)
 }
 
-func (c *Client) getSnapMirror(snapMirror snapMirrorRequest, vol string, clientID string, isSaas bool, connectorIP string) (string, error) {
+// getSnapMirrorStatus returns the relationship of the source working environment to the destination volume.
+// The destination working environment is matched as well when it is set, a source volume can fan out to
+// destination volumes of the same name on several working environments.
+// An empty destination volume name is returned if it does not exist.
+func (c *Client) getSnapMirrorStatus(snapMirror snapMirrorRequest, vol string, clientID string, isSaas bool, connectorIP string) (snapMirrorStatusResponse, error) {
 
 	var result []snapMirrorStatusResponse
 
 	accessTokenResult, err := c.getAccessToken()
 	if err != nil {
-		log.Print("in createSnapMirror request, failed to get AccessToken")
-		return "", err
+		log.Print("in getSnapMirrorStatus request, failed to get AccessToken")
+		return snapMirrorStatusResponse{}, err
 	}
 	c.Token = accessTokenResult.Token
 
@@ -474,22 +515,166 @@ func (c *Client) getSnapMirror(snapMirror snapMirrorRequest, vol string, clientI
 
 	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
 	if err != nil {
-		log.Print("getSnapMirror request failed ", statusCode)
-		return "", err
+		log.Print("getSnapMirrorStatus request failed ", statusCode)
+		return snapMirrorStatusResponse{}, err
 	}
-	responseError := apiResponseChecker(statusCode, response, "getSnapMirror")
+	responseError := apiResponseChecker(statusCode, response, "getSnapMirrorStatus")
 	if responseError != nil {
-		return "", responseError
+		return snapMirrorStatusResponse{}, responseError
 	}
 	if err := json.Unmarshal(response, &result); err != nil {
-		log.Print("Failed to unmarshall response from getSnapMirror ", err)
-		return "", err
+		log.Print("Failed to unmarshall response from getSnapMirrorStatus ", err)
+		return snapMirrorStatusResponse{}, err
 	}
+	destinationWEID := getSnapMirrorDestinationID(snapMirror)
 	for _, sm := range result {
-		if sm.Destination.VolumeName == vol {
-			return vol, nil
+		if sm.Destination.VolumeName != vol {
+			continue
+		}
+		if destinationWEID != "" && sm.Destination.WorkingEnvironmentID != "" && sm.Destination.WorkingEnvironmentID != destinationWEID {
+			continue
 		}
+		return sm, nil
+	}
+
+	return snapMirrorStatusResponse{}, nil
+}
+
+// getCurrentSnapMirrorStatus returns the relationship to the destination volume, or to the source volume once
+// the relationship was reversed. An empty destination volume name is returned if it does not exist.
+func (c *Client) getCurrentSnapMirrorStatus(snapMirror snapMirrorRequest, reversed bool, clientID string, isSaas bool, connectorIP string) (snapMirrorStatusResponse, error) {
+	if !reversed {
+		return c.getSnapMirrorStatus(snapMirror, snapMirror.ReplicationVolume.DestinationVolumeName, clientID, isSaas, connectorIP)
+	}
+	reversedSnapMirror := snapMirrorRequest{}
+	reversedSnapMirror.ReplicationRequest.SourceWorkingEnvironmentID = snapMirror.ReplicationRequest.DestinationWorkingEnvironmentID
+	reversedSnapMirror.ReplicationRequest.DestinationWorkingEnvironmentID = snapMirror.ReplicationRequest.SourceWorkingEnvironmentID
+	return c.getSnapMirrorStatus(reversedSnapMirror, snapMirror.ReplicationVolume.SourceVolumeName, clientID, isSaas, connectorIP)
+}
+
+// updateSnapMirror modifies the policy, schedule and max transfer rate of the relationship to the destination volume
+func (c *Client) updateSnapMirror(snapMirror snapMirrorRequest, request updateSnapMirrorRequest, clientID string, isSaas bool, connectorIP string) error {
+
+	accessTokenResult, err := c.getAccessToken()
+	if err != nil {
+		log.Print("in updateSnapMirror request, failed to get AccessToken")
+		return err
+	}
+	c.Token = accessTokenResult.Token
+	baseURL := fmt.Sprintf("/occm/api/replication/%s/%s/%s", snapMirror.ReplicationRequest.DestinationWorkingEnvironmentID, snapMirror.ReplicationVolume.DestinationSvmName, snapMirror.ReplicationVolume.DestinationVolumeName)
+
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+
+	params := structs.Map(request)
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("PUT", baseURL, params, c.Token, hostType, clientID)
+	if err != nil {
+		log.Printf("updateSnapMirror request failed with statusCode:%v, Error:%v", statusCode, err)
+		return err
+	}
+
+	responseError := apiResponseChecker(statusCode, response, "updateSnapMirror")
+	if responseError != nil {
+		return responseError
 	}
 
-	return "", nil
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, "snapmirror", "update", 10, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "snapmirror", "update", 10, 10, clientID, connectorIP)
+	}
+
+	return err
+}
+
+// snapMirrorOperation runs a lifecycle operation (quiesce, resume, break, resync or reverse-resync) on the relationship
+// of the destination volume and waits for it to complete
+func (c *Client) snapMirrorOperation(operation string, workingEnvironmentID string, svmName string, volumeName string, clientID string, isSaas bool, connectorIP string) error {
+	log.Printf("snapMirrorOperation %s on %s/%s", operation, svmName, volumeName)
+
+	accessTokenResult, err := c.getAccessToken()
+	if err != nil {
+		log.Print("in snapMirrorOperation request, failed to get AccessToken")
+		return err
+	}
+	c.Token = accessTokenResult.Token
+	baseURL := fmt.Sprintf("/occm/api/replication/%s/%s/%s/%s", operation, workingEnvironmentID, svmName, volumeName)
+
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("POST", baseURL, nil, c.Token, hostType, clientID)
+	if err != nil {
+		log.Printf("snapMirrorOperation %s request failed with statusCode:%v, Error:%v", operation, statusCode, err)
+		return err
+	}
+
+	responseError := apiResponseChecker(statusCode, response, "snapMirrorOperation")
+	if responseError != nil {
+		return responseError
+	}
+
+	// a resync transfers the changes since the common snapshot, wait up to an hour
+	retries := 60
+	if operation == "resync" || operation == "reverse-resync" {
+		retries = 360
+	}
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, "snapmirror", operation, retries, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "snapmirror", operation, retries, 10, clientID, connectorIP)
+	}
+
+	return err
+}
+
+// getSnapMirrorState returns the state of the relationship: snapmirrored, broken_off, quiesced or uninitialized.
+// Synchronous relationships report in_sync once mirrored, which is returned as snapmirrored.
+func getSnapMirrorState(relationship snapMirrorStatusResponse) string {
+	if strings.EqualFold(relationship.RelationshipStatus, "quiesced") {
+		return "quiesced"
+	}
+	state := strings.Replace(strings.ToLower(relationship.MirrorState), "-", "_", -1)
+	if state == "in_sync" {
+		return "snapmirrored"
+	}
+	return state
+}
+
+// getLagTimeSeconds returns the lag time of a relationship in seconds
+func getLagTimeSeconds(lag sizeUnit) int {
+	switch strings.ToLower(lag.Unit) {
+	case "minutes", "minute":
+		return lag.Size * 60
+	case "hours", "hour":
+		return lag.Size * 3600
+	case "days", "day":
+		return lag.Size * 86400
+	default:
+		return lag.Size
+	}
+}
+
+// getLastTransferEnd returns the end of the last transfer in RFC 3339 format, the API returns it in epoch milliseconds
+func getLastTransferEnd(timestamp int64) string {
+	if timestamp == 0 {
+		return ""
+	}
+	return time.Unix(0, timestamp*int64(time.Millisecond)).UTC().Format(time.RFC3339)
+}
+
+// getMaxTransferRate returns the max transfer rate of a relationship in kilobytes per second
+func getMaxTransferRate(rate sizeUnit) (int, error) {
+	if rate.Unit == "" {
+		return rate.Size, nil
+	}
+	size, err := convertSizeUnit(float64(rate.Size), rate.Unit, "KB")
+	if err != nil {
+		return 0, fmt.Errorf("cannot read max transfer rate: %s", err)
+	}
+	return int(size), nil
 }
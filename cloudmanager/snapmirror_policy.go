@@ -0,0 +1,195 @@
+package cloudmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/fatih/structs"
+)
+
+// snapMirrorPolicyRequest the users input for creating or updating a snapmirror policy
+type snapMirrorPolicyRequest struct {
+	Name                 string                 `structs:"name"`
+	PolicyType           string                 `structs:"policyType"`
+	Comment              string                 `structs:"comment,omitempty"`
+	Rules                []snapMirrorPolicyRule `structs:"rules"`
+	WorkingEnvironmentID string                 `structs:"workingEnvironmentId"`
+}
+
+// snapMirrorPolicyRule a retention rule of a snapmirror policy, snapshots with the label are kept on the destination
+type snapMirrorPolicyRule struct {
+	SnapmirrorLabel string `structs:"snapmirrorLabel" json:"snapmirrorLabel"`
+	Keep            int    `structs:"keep" json:"keep"`
+	Schedule        string `structs:"schedule,omitempty" json:"schedule"`
+}
+
+// snapMirrorPolicyResponse describes a snapmirror policy returned by the API
+type snapMirrorPolicyResponse struct {
+	Name       string                 `json:"name"`
+	PolicyType string                 `json:"policyType"`
+	Comment    string                 `json:"comment"`
+	Rules      []snapMirrorPolicyRule `json:"rules"`
+}
+
+// cronScheduleRequest the users input for creating or updating a cron schedule. An empty list matches every value.
+type cronScheduleRequest struct {
+	Name                 string `structs:"name"`
+	Minutes              []int  `structs:"minutes"`
+	Hours                []int  `structs:"hours"`
+	DaysOfMonth          []int  `structs:"daysOfMonth"`
+	Weekdays             []int  `structs:"weekdays"`
+	Months               []int  `structs:"months"`
+	WorkingEnvironmentID string `structs:"workingEnvironmentId"`
+}
+
+// cronScheduleResponse describes a cron schedule returned by the API
+type cronScheduleResponse struct {
+	Name        string `json:"name"`
+	Minutes     []int  `json:"minutes"`
+	Hours       []int  `json:"hours"`
+	DaysOfMonth []int  `json:"daysOfMonth"`
+	Weekdays    []int  `json:"weekdays"`
+	Months      []int  `json:"months"`
+}
+
+func (c *Client) createSnapMirrorPolicy(request snapMirrorPolicyRequest, clientID string, isSaas bool, connectorIP string) error {
+	log.Print("On createSnapMirrorPolicy... ")
+	baseURL := fmt.Sprintf("/occm/api/replication/policies/%s", request.WorkingEnvironmentID)
+	return c.callReplicationConfigAPI("POST", baseURL, structs.Map(request), "createSnapMirrorPolicy", "snapmirror policy", "create", clientID, isSaas, connectorIP)
+}
+
+// getSnapMirrorPolicy returns the snapmirror policy with the given name. An empty name is returned if it does not exist.
+func (c *Client) getSnapMirrorPolicy(workingEnvironmentID string, name string, clientID string, isSaas bool, connectorIP string) (snapMirrorPolicyResponse, error) {
+	log.Printf("getSnapMirrorPolicy %s", name)
+	var result snapMirrorPolicyResponse
+	accessTokenResult, err := c.getAccessToken()
+	if err != nil {
+		log.Print("in getSnapMirrorPolicy request, failed to get AccessToken")
+		return result, err
+	}
+	c.Token = accessTokenResult.Token
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	baseURL := fmt.Sprintf("/occm/api/replication/policies/%s", workingEnvironmentID)
+	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("getSnapMirrorPolicy request failed ", statusCode)
+		return result, err
+	}
+	responseError := apiResponseChecker(statusCode, response, "getSnapMirrorPolicy")
+	if responseError != nil {
+		return result, responseError
+	}
+	var policies []snapMirrorPolicyResponse
+	if err := json.Unmarshal(response, &policies); err != nil {
+		log.Print("Failed to unmarshall response from getSnapMirrorPolicy ", err)
+		return result, err
+	}
+	for _, policy := range policies {
+		if policy.Name == name {
+			return policy, nil
+		}
+	}
+	log.Printf("Cannot find snapmirror policy %s", name)
+	return result, nil
+}
+
+func (c *Client) updateSnapMirrorPolicy(request snapMirrorPolicyRequest, clientID string, isSaas bool, connectorIP string) error {
+	log.Print("On updateSnapMirrorPolicy... ")
+	baseURL := fmt.Sprintf("/occm/api/replication/policies/%s/%s", request.WorkingEnvironmentID, request.Name)
+	return c.callReplicationConfigAPI("PUT", baseURL, structs.Map(request), "updateSnapMirrorPolicy", "snapmirror policy", "update", clientID, isSaas, connectorIP)
+}
+
+func (c *Client) deleteSnapMirrorPolicy(workingEnvironmentID string, name string, clientID string, isSaas bool, connectorIP string) error {
+	log.Print("On deleteSnapMirrorPolicy... ")
+	baseURL := fmt.Sprintf("/occm/api/replication/policies/%s/%s", workingEnvironmentID, name)
+	return c.callReplicationConfigAPI("DELETE", baseURL, nil, "deleteSnapMirrorPolicy", "snapmirror policy", "delete", clientID, isSaas, connectorIP)
+}
+
+func (c *Client) createCronSchedule(request cronScheduleRequest, clientID string, isSaas bool, connectorIP string) error {
+	log.Print("On createCronSchedule... ")
+	baseURL := fmt.Sprintf("/occm/api/replication/schedules/%s", request.WorkingEnvironmentID)
+	return c.callReplicationConfigAPI("POST", baseURL, structs.Map(request), "createCronSchedule", "cron schedule", "create", clientID, isSaas, connectorIP)
+}
+
+// getCronSchedule returns the cron schedule with the given name. An empty name is returned if it does not exist.
+func (c *Client) getCronSchedule(workingEnvironmentID string, name string, clientID string, isSaas bool, connectorIP string) (cronScheduleResponse, error) {
+	log.Printf("getCronSchedule %s", name)
+	var result cronScheduleResponse
+	accessTokenResult, err := c.getAccessToken()
+	if err != nil {
+		log.Print("in getCronSchedule request, failed to get AccessToken")
+		return result, err
+	}
+	c.Token = accessTokenResult.Token
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	baseURL := fmt.Sprintf("/occm/api/replication/schedules/%s", workingEnvironmentID)
+	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("getCronSchedule request failed ", statusCode)
+		return result, err
+	}
+	responseError := apiResponseChecker(statusCode, response, "getCronSchedule")
+	if responseError != nil {
+		return result, responseError
+	}
+	var schedules []cronScheduleResponse
+	if err := json.Unmarshal(response, &schedules); err != nil {
+		log.Print("Failed to unmarshall response from getCronSchedule ", err)
+		return result, err
+	}
+	for _, schedule := range schedules {
+		if schedule.Name == name {
+			return schedule, nil
+		}
+	}
+	log.Printf("Cannot find cron schedule %s", name)
+	return result, nil
+}
+
+func (c *Client) updateCronSchedule(request cronScheduleRequest, clientID string, isSaas bool, connectorIP string) error {
+	log.Print("On updateCronSchedule... ")
+	baseURL := fmt.Sprintf("/occm/api/replication/schedules/%s/%s", request.WorkingEnvironmentID, request.Name)
+	return c.callReplicationConfigAPI("PUT", baseURL, structs.Map(request), "updateCronSchedule", "cron schedule", "update", clientID, isSaas, connectorIP)
+}
+
+func (c *Client) deleteCronSchedule(workingEnvironmentID string, name string, clientID string, isSaas bool, connectorIP string) error {
+	log.Print("On deleteCronSchedule... ")
+	baseURL := fmt.Sprintf("/occm/api/replication/schedules/%s/%s", workingEnvironmentID, name)
+	return c.callReplicationConfigAPI("DELETE", baseURL, nil, "deleteCronSchedule", "cron schedule", "delete", clientID, isSaas, connectorIP)
+}
+
+// callReplicationConfigAPI sends a request for the replication policies and schedules of a working environment and waits for it to complete
+func (c *Client) callReplicationConfigAPI(method string, baseURL string, params map[string]interface{}, functionName string, actionName string, task string, clientID string, isSaas bool, connectorIP string) error {
+	accessTokenResult, err := c.getAccessToken()
+	if err != nil {
+		log.Print("in " + functionName + " request, failed to get AccessToken")
+		return err
+	}
+	c.Token = accessTokenResult.Token
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod(method, baseURL, params, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print(functionName+" request failed ", statusCode)
+		return err
+	}
+	responseError := apiResponseChecker(statusCode, response, functionName)
+	if responseError != nil {
+		return responseError
+	}
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, actionName, task, 10, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, actionName, task, 10, 10, clientID, connectorIP)
+	}
+	return err
+}
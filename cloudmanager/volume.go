@@ -6,6 +6,7 @@ import (
 	"log"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/fatih/structs"
 	"github.com/hashicorp/terraform/helper/schema"
@@ -36,12 +37,49 @@ type volumeRequest struct {
 	ShareInfo                 shareInfoRequest       `structs:"shareInfo,omitempty"`
 	ShareInfoUpdate           shareInfoUpdateRequest `structs:"shareInfo,omitempty"`
 	IscsiInfo                 iscsiInfo              `structs:"iscsiInfo,omitempty"`
+	NvmeInfo                  nvmeInfo               `structs:"nvmeInfo,omitempty"`
 	FileSystemID              string                 `structs:"fileSystemId,omitempty"`
 	TenantID                  string                 `structs:"tenantId,omitempty"`
 	EnableStorageEfficiency   bool                   `structs:"enableStorageEfficiency"`
 	VolumeTags                []volumeTag            `structs:"volumeTags,omitempty"`
 	VolumeFSXTags             []volumeTag            `structs:"awsTags,omitempty"`
 	Comment                   string                 `structs:"comment,omitempty"`
+	SecurityStyle             string                 `structs:"securityStyle,omitempty"`
+	VolumeStyle               string                 `structs:"volumeStyle,omitempty"`
+	Encryption                volumeEncryption       `structs:"encryption,omitempty"`
+	SnaplockInfo              snaplockInfo           `structs:"snaplockInfo,omitempty"`
+	AntiRansomwareState       string                 `structs:"antiRansomwareState,omitempty"`
+	FlexGroupInfo             flexGroupInfo          `structs:"flexGroupInfo,omitempty"`
+}
+
+// snaplockInfo describes the SnapLock (WORM) settings of a volume. Retention periods are ISO 8601 durations.
+type snaplockInfo struct {
+	SnaplockType     string `structs:"snaplockType" json:"snaplockType"`
+	DefaultRetention string `structs:"defaultRetention,omitempty" json:"defaultRetention"`
+	MinimumRetention string `structs:"minimumRetention,omitempty" json:"minimumRetention"`
+	MaximumRetention string `structs:"maximumRetention,omitempty" json:"maximumRetention"`
+	AutocommitPeriod string `structs:"autocommitPeriod,omitempty" json:"autocommitPeriod"`
+}
+
+// antiRansomwareStatus describes the Autonomous Ransomware Protection (ARP) status of a volume
+type antiRansomwareStatus struct {
+	State             string `json:"state"`
+	AttackProbability string `json:"attackProbability"`
+	SuspectFilesCount int    `json:"suspectFilesCount"`
+	DryRunStartTime   string `json:"dryRunStartTime"`
+	AttackDetectedBy  string `json:"attackDetectedBy"`
+}
+
+// volumeEncryption describes the NetApp Volume Encryption (NVE) settings of a volume
+type volumeEncryption struct {
+	Enabled    bool   `structs:"enabled" json:"enabled"`
+	KeyManager string `structs:"keyManager,omitempty" json:"keyManager"`
+}
+
+// flexGroupInfo describes the placement of the constituents of a flexgroup volume
+type flexGroupInfo struct {
+	Aggregates       []string `structs:"aggregates,omitempty"`
+	ConstituentCount int      `structs:"constituentCount,omitempty"`
 }
 
 type avsOnVolumeRequest struct {
@@ -96,7 +134,22 @@ type volumeResponse struct {
 	ShareInfo              []shareInfoResponse      `json:"shareInfo"`
 	MountPoint             string                   `json:"mountPoint"`
 	IscsiEnabled           bool                     `json:"iscsiEnabled"`
+	NvmeEnabled            bool                     `json:"nvmeEnabled"`
+	NvmeSubsystemName      string                   `json:"nvmeSubsystemName"`
 	Comment                string                   `json:"comment"`
+	SecurityStyle          string                   `json:"securityStyle"`
+	VolumeStyle            string                   `json:"volumeStyle"`
+	Encryption             volumeEncryption         `json:"encryption"`
+	SnaplockInfo           snaplockInfo             `json:"snaplockInfo"`
+	AntiRansomwareState    string                   `json:"antiRansomwareState"`
+	Constituents           []constituentResponse    `json:"constituents"`
+}
+
+// constituentResponse describes a constituent of a flexgroup volume
+type constituentResponse struct {
+	Name          string   `json:"name"`
+	AggregateName string   `json:"aggregateName"`
+	Size          capacity `json:"size"`
 }
 
 // ExportPolicyInfo describes the export policy section.
@@ -192,6 +245,15 @@ type iscsiInfo struct {
 	Igroups []string `structs:"igroups,omitempty"`
 }
 
+type nvmeInfo struct {
+	OsName                   string `structs:"osName,omitempty"`
+	SubsystemCreationRequest struct {
+		SubsystemName string   `structs:"subsystemName,omitempty"`
+		HostNqns      []string `structs:"hostNqns,omitempty"`
+	} `structs:"subsystemCreationRequest,omitempty"`
+	SubsystemName string `structs:"subsystemName,omitempty"`
+}
+
 type initiator struct {
 	AliasName              string `structs:"aliasName,omitempty"`
 	Iqn                    string `structs:"iqn,omitempty"`
@@ -501,6 +563,7 @@ type igroup struct {
 	IgroupName             string   `json:"igroupName"`
 	OsType                 string   `json:"osType"`
 	PortsetName            string   `json:"portsetName"`
+	Portsets               []string `json:"portsets"`
 	IgroupType             string   `json:"igroupType"`
 	Initiators             []string `json:"initiators"`
 	WorkingEnvironmentID   string   `structs:"workingEnvironmentId"`
@@ -541,6 +604,309 @@ func (c *Client) getIgroups(request igroup, clientID string, isSaas bool, connec
 	return result, nil
 }
 
+type nvmeSubsystem struct {
+	SubsystemName          string   `json:"subsystemName"`
+	OsType                 string   `json:"osType"`
+	HostNqns               []string `json:"hostNqns"`
+	WorkingEnvironmentID   string   `structs:"workingEnvironmentId"`
+	SvmName                string   `structs:"svmName"`
+	WorkingEnvironmentType string   `structs:"workingEnvironmentType,omitempty"`
+}
+
+type nvmeSubsystemHostRequest struct {
+	HostNqns []string `structs:"hostNqns"`
+}
+
+func (c *Client) getNvmeSubsystems(request nvmeSubsystem, clientID string, isSaas bool, connectorIP string) ([]nvmeSubsystem, error) {
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+
+	baseURL, _, err := c.getAPIRoot(request.WorkingEnvironmentID, clientID, isSaas, connectorIP)
+	var result []nvmeSubsystem
+	if err != nil {
+		return result, err
+	}
+	baseURL = fmt.Sprintf("%s/volumes/nvme-subsystems/%s/%s", baseURL, request.WorkingEnvironmentID, request.SvmName)
+	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("getNvmeSubsystems request failed ", statusCode)
+		return result, err
+	}
+	responseError := apiResponseChecker(statusCode, response, "getNvmeSubsystems")
+	if responseError != nil {
+		return result, responseError
+	}
+	if err := json.Unmarshal(response, &result); err != nil {
+		log.Print("Failed to unmarshall response from getNvmeSubsystems ", err)
+		return result, err
+	}
+	return result, nil
+}
+
+func (c *Client) addNvmeSubsystemHosts(request nvmeSubsystem, hostNqns []string, clientID string, isSaas bool, connectorIP string) error {
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+
+	baseURL, _, err := c.getAPIRoot(request.WorkingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/volumes/nvme-subsystems/%s/%s/%s/hosts", baseURL, request.WorkingEnvironmentID, request.SvmName, request.SubsystemName)
+	params := structs.Map(nvmeSubsystemHostRequest{HostNqns: hostNqns})
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("POST", baseURL, params, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("addNvmeSubsystemHosts request failed ", statusCode)
+		return err
+	}
+	responseError := apiResponseChecker(statusCode, response, "addNvmeSubsystemHosts")
+	if responseError != nil {
+		return responseError
+	}
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, "nvme subsystem host", "add", 10, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "nvme subsystem host", "add", 10, 10, clientID, connectorIP)
+	}
+	return err
+}
+
+func (c *Client) removeNvmeSubsystemHost(request nvmeSubsystem, hostNqn string, clientID string, isSaas bool, connectorIP string) error {
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+
+	baseURL, _, err := c.getAPIRoot(request.WorkingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/volumes/nvme-subsystems/%s/%s/%s/hosts/%s", baseURL, request.WorkingEnvironmentID, request.SvmName, request.SubsystemName, hostNqn)
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("DELETE", baseURL, nil, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("removeNvmeSubsystemHost request failed ", statusCode)
+		return err
+	}
+	responseError := apiResponseChecker(statusCode, response, "removeNvmeSubsystemHost")
+	if responseError != nil {
+		return responseError
+	}
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, "nvme subsystem host", "remove", 10, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "nvme subsystem host", "remove", 10, 10, clientID, connectorIP)
+	}
+	return err
+}
+
+// volumeRenameRequest the new name of a volume
+type volumeRenameRequest struct {
+	NewName string `structs:"newName"`
+}
+
+func (c *Client) renameVolume(workingEnvironmentID string, svmName string, volumeName string, newName string, clientID string, isSaas bool, connectorIP string) error {
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+
+	baseURL, _, err := c.getAPIRoot(workingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/volumes/%s/%s/%s", baseURL, workingEnvironmentID, svmName, volumeName)
+	params := structs.Map(volumeRenameRequest{NewName: newName})
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("PUT", baseURL, params, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("renameVolume request failed ", statusCode)
+		return err
+	}
+	responseError := apiResponseChecker(statusCode, response, "renameVolume")
+	if responseError != nil {
+		return responseError
+	}
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, "volume", "rename", 40, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "volume", "rename", 40, 10, clientID, connectorIP)
+	}
+	return err
+}
+
+// volumeMoveRequest the target of a volume move
+type volumeMoveRequest struct {
+	TargetAggregate struct {
+		Name string `structs:"name"`
+	} `structs:"targetAggregate"`
+}
+
+// volumeMoveStatus the progress of a volume move
+type volumeMoveStatus struct {
+	State           string `json:"state"`
+	Phase           string `json:"phase"`
+	PercentComplete int    `json:"percentComplete"`
+}
+
+// moveVolume moves the volume to another aggregate and waits for the cut-over to complete
+func (c *Client) moveVolume(workingEnvironmentID string, svmName string, volumeName string, request volumeMoveRequest, clientID string, isSaas bool, connectorIP string) error {
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+
+	baseURL, _, err := c.getAPIRoot(workingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/volumes/%s/%s/%s/move", baseURL, workingEnvironmentID, svmName, volumeName)
+	params := structs.Map(request)
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("POST", baseURL, params, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("moveVolume request failed ", statusCode)
+		return err
+	}
+	responseError := apiResponseChecker(statusCode, response, "moveVolume")
+	if responseError != nil {
+		return responseError
+	}
+	// a volume move copies all the data of the volume before cut-over, wait up to 6 hours
+	log.Printf("Volume move of %s to aggregate %s started, waiting for cut-over", volumeName, request.TargetAggregate.Name)
+	retries := 2160
+	for {
+		var status int
+		var failureErrorMessage string
+		if isSaas {
+			status, failureErrorMessage, err = c.checkTaskStatus(onCloudRequestID, clientID)
+		} else {
+			status, failureErrorMessage, err = c.checkTaskStatusForNotSaas(onCloudRequestID, clientID, connectorIP)
+		}
+		if err != nil {
+			return err
+		}
+		if status == 1 {
+			break
+		} else if status == -1 {
+			return fmt.Errorf("failed to move volume, error: %s", failureErrorMessage)
+		}
+		if retries == 0 {
+			log.Print("Taking too long to move volume ", volumeName)
+			return fmt.Errorf("taking too long for volume to move or not properly setup")
+		}
+		// the progress is only logged, the task status decides when the move is done
+		moveStatus, err := c.getVolumeMoveStatus(workingEnvironmentID, svmName, volumeName, clientID, isSaas, connectorIP)
+		if err != nil {
+			log.Printf("Cannot get the move progress of volume %s: %s", volumeName, err)
+		} else {
+			log.Printf("Volume move of %s to aggregate %s: state %s, phase %s, %d%% complete", volumeName, request.TargetAggregate.Name, moveStatus.State, moveStatus.Phase, moveStatus.PercentComplete)
+		}
+		time.Sleep(10 * time.Second)
+		retries--
+	}
+	log.Printf("Volume move of %s to aggregate %s completed", volumeName, request.TargetAggregate.Name)
+	return nil
+}
+
+// getVolumeMoveStatus returns the progress of the current move of the volume
+func (c *Client) getVolumeMoveStatus(workingEnvironmentID string, svmName string, volumeName string, clientID string, isSaas bool, connectorIP string) (volumeMoveStatus, error) {
+	var result volumeMoveStatus
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+
+	baseURL, _, err := c.getAPIRoot(workingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		return result, err
+	}
+	baseURL = fmt.Sprintf("%s/volumes/%s/%s/%s/move", baseURL, workingEnvironmentID, svmName, volumeName)
+	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("getVolumeMoveStatus request failed ", statusCode)
+		return result, err
+	}
+	responseError := apiResponseChecker(statusCode, response, "getVolumeMoveStatus")
+	if responseError != nil {
+		return result, responseError
+	}
+	if err := json.Unmarshal(response, &result); err != nil {
+		log.Print("Failed to unmarshall response from getVolumeMoveStatus ", err)
+		return result, err
+	}
+	return result, nil
+}
+
+// flexGroupExpandRequest the aggregates or the number of constituents added to a flexgroup volume
+type flexGroupExpandRequest struct {
+	Aggregates       []string `structs:"aggregates,omitempty"`
+	ConstituentCount int      `structs:"constituentCount,omitempty"`
+}
+
+// volumeResizeRequest the new size of a volume
+type volumeResizeRequest struct {
+	Size size `structs:"size"`
+}
+
+func (c *Client) expandFlexGroup(workingEnvironmentID string, svmName string, volumeName string, request flexGroupExpandRequest, clientID string, isSaas bool, connectorIP string) error {
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+
+	baseURL, _, err := c.getAPIRoot(workingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/volumes/%s/%s/%s/expand", baseURL, workingEnvironmentID, svmName, volumeName)
+	params := structs.Map(request)
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("POST", baseURL, params, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("expandFlexGroup request failed ", statusCode)
+		return err
+	}
+	responseError := apiResponseChecker(statusCode, response, "expandFlexGroup")
+	if responseError != nil {
+		return responseError
+	}
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, "flexgroup", "expand", 40, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "flexgroup", "expand", 40, 10, clientID, connectorIP)
+	}
+	return err
+}
+
+func (c *Client) resizeVolume(workingEnvironmentID string, svmName string, volumeName string, request volumeResizeRequest, clientID string, isSaas bool, connectorIP string) error {
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+
+	baseURL, _, err := c.getAPIRoot(workingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/volumes/%s/%s/%s/resize", baseURL, workingEnvironmentID, svmName, volumeName)
+	params := structs.Map(request)
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("PUT", baseURL, params, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("resizeVolume request failed ", statusCode)
+		return err
+	}
+	responseError := apiResponseChecker(statusCode, response, "resizeVolume")
+	if responseError != nil {
+		return responseError
+	}
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, "volume", "resize", 40, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "volume", "resize", 40, 10, clientID, connectorIP)
+	}
+	return err
+}
+
 func (c *Client) checkCifsExists(workingEnvironmentType string, id string, svm string, clientID string, isSaas bool, connectorIP string) (bool, error) {
 	hostType := "CloudManagerHost"
 	if !isSaas {
@@ -576,20 +942,20 @@ func (c *Client) checkCifsExists(workingEnvironmentType string, id string, svm s
 	return false, nil
 }
 
-func convertSizeUnit(size float64, from string, to string) float64 {
-	if strings.ToUpper(from) == "GB" && strings.ToUpper(to) == "GB" {
-		return size
-	}
-	if strings.ToUpper(from) == "GB" && strings.ToUpper(to) == "TB" {
-		size = size / 1024
-	}
-	if strings.ToUpper(from) == "GB" && strings.ToUpper(to) == "B" {
-		size = size * 1073741824
+// sizeUnits the number of bytes in each size unit returned by the API
+var sizeUnits = map[string]float64{"B": 1, "BYTE": 1, "BYTES": 1, "KB": 1 << 10, "MB": 1 << 20, "GB": 1 << 30, "TB": 1 << 40}
+
+// convertSizeUnit converts a size between units, unknown units are rejected instead of being read as another unit
+func convertSizeUnit(size float64, from string, to string) (float64, error) {
+	fromBytes, ok := sizeUnits[strings.ToUpper(from)]
+	if !ok {
+		return 0, fmt.Errorf("unsupported size unit %s", from)
 	}
-	if strings.ToUpper(from) == "B" && strings.ToUpper(to) == "GB" {
-		size = size / 1073741824
+	toBytes, ok := sizeUnits[strings.ToUpper(to)]
+	if !ok {
+		return 0, fmt.Errorf("unsupported size unit %s", to)
 	}
-	return size
+	return size * fromBytes / toBytes, nil
 }
 
 func (c *Client) setCommonAttributes(WorkingEnvironmentType string, d *schema.ResourceData, volume *volumeRequest, clientID string) error {
@@ -652,23 +1018,33 @@ func (c *Client) setCommonAttributes(WorkingEnvironmentType string, d *schema.Re
 // createSnapshotPolicy
 func (c *Client) createSnapshotPolicy(workingEnviromentID string, snapshotPolicyName string, set *schema.Set, clientID string, isSaas bool, connectorIP string) error {
 	log.Print("createSnapshotPolicy: ", snapshotPolicyName)
+	snapshotPolicy := buildSnapshotPolicyRequest(workingEnviromentID, snapshotPolicyName, nil)
+	for _, v := range set.List() {
+		schedules := v.(map[string]interface{})
+		snapshotPolicy = buildSnapshotPolicyRequest(workingEnviromentID, snapshotPolicyName, schedules["schedule"].([]interface{}))
+	}
+	return c.createSnapshotPolicyWithSchedules(snapshotPolicy, clientID, isSaas, connectorIP)
+}
+
+// buildSnapshotPolicyRequest builds the snapshot policy request from a list of schedule blocks
+func buildSnapshotPolicyRequest(workingEnviromentID string, snapshotPolicyName string, scheduleSet []interface{}) createSnapshotPolicyRequest {
 	snapshotPolicy := createSnapshotPolicyRequest{}
 	snapshotPolicy.SnapshotPolicyName = snapshotPolicyName
 	snapshotPolicy.WorkingEnvironmentID = workingEnviromentID
-	for _, v := range set.List() {
-		schedules := v.(map[string]interface{})
-		scheduleSet := schedules["schedule"].([]interface{})
-		scheduleConfigs := make([]scheduleReq, 0, len(scheduleSet))
-		for _, x := range scheduleSet {
-			snapshotPolicySchedule := scheduleReq{}
-			scheduleConfig := x.(map[string]interface{})
-			snapshotPolicySchedule.ScheduleType = scheduleConfig["schedule_type"].(string)
-			snapshotPolicySchedule.Retention = scheduleConfig["retention"].(int)
-
-			scheduleConfigs = append(scheduleConfigs, snapshotPolicySchedule)
-		}
-		snapshotPolicy.Schedules = scheduleConfigs
+	scheduleConfigs := make([]scheduleReq, 0, len(scheduleSet))
+	for _, x := range scheduleSet {
+		snapshotPolicySchedule := scheduleReq{}
+		scheduleConfig := x.(map[string]interface{})
+		snapshotPolicySchedule.ScheduleType = scheduleConfig["schedule_type"].(string)
+		snapshotPolicySchedule.Retention = scheduleConfig["retention"].(int)
+
+		scheduleConfigs = append(scheduleConfigs, snapshotPolicySchedule)
 	}
+	snapshotPolicy.Schedules = scheduleConfigs
+	return snapshotPolicy
+}
+
+func (c *Client) createSnapshotPolicyWithSchedules(snapshotPolicy createSnapshotPolicyRequest, clientID string, isSaas bool, connectorIP string) error {
 	baseURL, _, err := c.getAPIRoot(snapshotPolicy.WorkingEnvironmentID, clientID, isSaas, connectorIP)
 
 	hostType := "CloudManagerHost"
@@ -701,13 +1077,86 @@ func (c *Client) createSnapshotPolicy(workingEnviromentID string, snapshotPolicy
 		return err
 	}
 
-	if c.findSnapshotPolicy(workingEnviromentID, snapshotPolicyName, clientID, isSaas, connectorIP) {
+	if c.findSnapshotPolicy(snapshotPolicy.WorkingEnvironmentID, snapshotPolicy.SnapshotPolicyName, clientID, isSaas, connectorIP) {
 		return nil
 	}
 
 	return fmt.Errorf("create snapshot policy failed")
 }
 
+// getSnapshotPolicy returns the snapshot policy of the working environment. An empty name is returned if it does not exist.
+func (c *Client) getSnapshotPolicy(workingEnviromentID string, snapshotPolicyName string, clientID string, isSaas bool, connectorIP string) (cvoSnapshotPolicy, error) {
+	resp, err := c.getCVOProperties(workingEnviromentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		log.Print("cannot find working environment ", workingEnviromentID)
+		return cvoSnapshotPolicy{}, err
+	}
+	for _, policy := range resp.SnapshotPolicies {
+		if policy.Name == snapshotPolicyName {
+			return policy, nil
+		}
+	}
+	log.Print("cannot find snapshot policy ", snapshotPolicyName)
+	return cvoSnapshotPolicy{}, nil
+}
+
+func (c *Client) updateSnapshotPolicy(snapshotPolicy createSnapshotPolicyRequest, clientID string, isSaas bool, connectorIP string) error {
+	log.Print("updateSnapshotPolicy: ", snapshotPolicy.SnapshotPolicyName)
+	baseURL, _, err := c.getAPIRoot(snapshotPolicy.WorkingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		return err
+	}
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	baseURL = fmt.Sprintf("%s/working-environments/%s/snapshot-policy/%s", baseURL, snapshotPolicy.WorkingEnvironmentID, snapshotPolicy.SnapshotPolicyName)
+	param := structs.Map(snapshotPolicy)
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("PUT", baseURL, param, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("updateSnapshotPolicy request failed ", statusCode)
+		return err
+	}
+	responseError := apiResponseChecker(statusCode, response, "updateSnapshotPolicy")
+	if responseError != nil {
+		return responseError
+	}
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, "snapshotPolicy", "update", 10, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "snapshotPolicy", "update", 10, 10, clientID, connectorIP)
+	}
+	return err
+}
+
+func (c *Client) deleteSnapshotPolicy(workingEnviromentID string, snapshotPolicyName string, clientID string, isSaas bool, connectorIP string) error {
+	log.Print("deleteSnapshotPolicy: ", snapshotPolicyName)
+	baseURL, _, err := c.getAPIRoot(workingEnviromentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		return err
+	}
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+	baseURL = fmt.Sprintf("%s/working-environments/%s/snapshot-policy/%s", baseURL, workingEnviromentID, snapshotPolicyName)
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("DELETE", baseURL, nil, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("deleteSnapshotPolicy request failed ", statusCode)
+		return err
+	}
+	responseError := apiResponseChecker(statusCode, response, "deleteSnapshotPolicy")
+	if responseError != nil {
+		return responseError
+	}
+	if isSaas {
+		err = c.waitOnCompletion(onCloudRequestID, "snapshotPolicy", "delete", 10, 10, clientID)
+	} else {
+		err = c.waitOnCompletionForNotSaas(onCloudRequestID, "snapshotPolicy", "delete", 10, 10, clientID, connectorIP)
+	}
+	return err
+}
+
 // findSnapshotPolicy
 func (c *Client) findSnapshotPolicy(workingEnviromentID string, snapshotPolicyName string, clientID string, isSaas bool, connectorIP string) bool {
 	resp, err := c.getCVOProperties(workingEnviromentID, clientID, isSaas, connectorIP)
@@ -809,3 +1258,32 @@ func (c *Client) syncAvsHosts(workingEnvironmentID string, svmName string, volum
 	}
 	return c.waitOnCompletionForNotSaas(onCloudRequestID, "volume", "sync-avs-hosts", 40, 10, clientID, connectorIP)
 }
+
+// getVolumeAntiRansomwareStatus returns the Autonomous Ransomware Protection status of the volume
+func (c *Client) getVolumeAntiRansomwareStatus(workingEnvironmentID string, svmName string, volumeName string, clientID string, isSaas bool, connectorIP string) (antiRansomwareStatus, error) {
+	hostType := "CloudManagerHost"
+	if !isSaas {
+		hostType = "http://" + connectorIP
+	}
+
+	var result antiRansomwareStatus
+	baseURL, _, err := c.getAPIRoot(workingEnvironmentID, clientID, isSaas, connectorIP)
+	if err != nil {
+		return result, err
+	}
+	baseURL = fmt.Sprintf("%s/volumes/%s/%s/%s/anti-ransomware", baseURL, workingEnvironmentID, svmName, volumeName)
+	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	if err != nil {
+		log.Print("getVolumeAntiRansomwareStatus request failed ", statusCode)
+		return result, err
+	}
+	responseError := apiResponseChecker(statusCode, response, "getVolumeAntiRansomwareStatus")
+	if responseError != nil {
+		return result, responseError
+	}
+	if err := json.Unmarshal(response, &result); err != nil {
+		log.Print("Failed to unmarshall response from getVolumeAntiRansomwareStatus ", err)
+		return result, err
+	}
+	return result, nil
+}